@@ -0,0 +1,206 @@
+package contactsheet
+
+import (
+    "errors"
+    "fmt"
+    "image"
+    "image/color"
+    "image/draw"
+    _ "image/gif"
+    "image/jpeg"
+    "image/png"
+    "io"
+    "os"
+    "path/filepath"
+    "strings"
+)
+
+// Entry is a single poster of a contact sheet.
+type Entry struct {
+    // PosterPath is the local poster image, a placeholder is drawn when empty or unreadable.
+    PosterPath string
+    Title      string
+    Year       string
+}
+
+// Config configures the layout of a contact sheet.
+type Config struct {
+    // Columns is the number of posters per row, defaulting to 5.
+    Columns int
+    // Rows is the number of rows per sheet, 0 meaning a single sheet holding every entry.
+    Rows int
+    // CellWidth and PosterHeight size the area of every poster, defaulting to 200x300.
+    CellWidth    int
+    PosterHeight int
+    // Padding separates the cells, defaulting to 10 when 0. A negative Padding
+    // leaves no space between the cells.
+    Padding int
+    // TextScale scales the 5x7 pixel font of the captions, defaulting to 2.
+    TextScale int
+}
+
+var (
+    background  = color.RGBA{R: 0x20, G: 0x20, B: 0x20, A: 0xff}
+    placeholder = color.RGBA{R: 0x50, G: 0x50, B: 0x50, A: 0xff}
+    foreground  = color.RGBA{R: 0xf0, G: 0xf0, B: 0xf0, A: 0xff}
+    dimmed      = color.RGBA{R: 0xa0, G: 0xa0, B: 0xa0, A: 0xff}
+)
+
+func (c Config) withDefaults() Config {
+    if c.Columns < 1 {
+        c.Columns = 5
+    }
+    if c.CellWidth < 1 {
+        c.CellWidth = 200
+    }
+    if c.PosterHeight < 1 {
+        c.PosterHeight = 300
+    }
+    if c.Padding < 0 {
+        c.Padding = 0
+    } else if c.Padding == 0 {
+        c.Padding = 10
+    }
+    if c.TextScale < 1 {
+        c.TextScale = 2
+    }
+    return c
+}
+
+// Render lays entries out in a grid, one sheet per Columns*Rows entries, with
+// the title and year written underneath every poster.
+func Render(entries []Entry, config Config) []*image.RGBA {
+    config = config.withDefaults()
+    perSheet := len(entries)
+    if config.Rows > 0 {
+        perSheet = config.Columns * config.Rows
+    }
+
+    var sheets []*image.RGBA
+    for start := 0; start < len(entries); start += perSheet {
+        end := start + perSheet
+        if end > len(entries) {
+            end = len(entries)
+        }
+        sheets = append(sheets, renderSheet(entries[start:end], config))
+    }
+    return sheets
+}
+
+func renderSheet(entries []Entry, config Config) *image.RGBA {
+    lineHeight := (glyphHeight + 3) * config.TextScale
+    cellHeight := config.PosterHeight + config.Padding/2 + 2*lineHeight
+    rows := (len(entries) + config.Columns - 1) / config.Columns
+    columns := config.Columns
+    if len(entries) < columns {
+        columns = len(entries)
+    }
+
+    width := columns*(config.CellWidth+config.Padding) + config.Padding
+    height := rows*(cellHeight+config.Padding) + config.Padding
+    sheet := image.NewRGBA(image.Rect(0, 0, width, height))
+    draw.Draw(sheet, sheet.Bounds(), image.NewUniform(background), image.Point{}, draw.Src)
+
+    for i, entry := range entries {
+        x := config.Padding + (i%config.Columns)*(config.CellWidth+config.Padding)
+        y := config.Padding + (i/config.Columns)*(cellHeight+config.Padding)
+        area := image.Rect(x, y, x+config.CellWidth, y+config.PosterHeight)
+
+        poster, err := loadImage(entry.PosterPath)
+        if err != nil {
+            draw.Draw(sheet, area, image.NewUniform(placeholder), image.Point{}, draw.Src)
+            label := fitText("no poster", config.CellWidth, config.TextScale)
+            drawText(sheet, x+(config.CellWidth-textWidth(label, config.TextScale))/2, y+config.PosterHeight/2, label, config.TextScale, dimmed)
+        } else {
+            drawScaled(sheet, area, poster)
+        }
+
+        textY := y + config.PosterHeight + config.Padding/2
+        title := fitText(entry.Title, config.CellWidth, config.TextScale)
+        drawText(sheet, x+(config.CellWidth-textWidth(title, config.TextScale))/2, textY, title, config.TextScale, foreground)
+        year := fitText(entry.Year, config.CellWidth, config.TextScale)
+        drawText(sheet, x+(config.CellWidth-textWidth(year, config.TextScale))/2, textY+lineHeight, year, config.TextScale, dimmed)
+    }
+    return sheet
+}
+
+func loadImage(path string) (image.Image, error) {
+    if path == "" {
+        return nil, errors.New("no poster")
+    }
+    file, err := os.Open(path)
+    if err != nil {
+        return nil, err
+    }
+    defer file.Close()
+
+    img, _, err := image.Decode(file)
+    return img, err
+}
+
+// drawScaled draws src into the centre of area, scaled to fit while keeping its aspect ratio.
+func drawScaled(dst *image.RGBA, area image.Rectangle, src image.Image) {
+    bounds := src.Bounds()
+    if bounds.Empty() {
+        return
+    }
+    width, height := area.Dx(), bounds.Dy()*area.Dx()/bounds.Dx()
+    if height > area.Dy() {
+        width, height = bounds.Dx()*area.Dy()/bounds.Dy(), area.Dy()
+    }
+    offsetX := area.Min.X + (area.Dx()-width)/2
+    offsetY := area.Min.Y + (area.Dy()-height)/2
+
+    // nearest neighbour keeps this to the standard library
+    for y := 0; y < height; y++ {
+        srcY := bounds.Min.Y + y*bounds.Dy()/height
+        for x := 0; x < width; x++ {
+            srcX := bounds.Min.X + x*bounds.Dx()/width
+            dst.Set(offsetX+x, offsetY+y, src.At(srcX, srcY))
+        }
+    }
+}
+
+// Encode writes img as png or jpeg.
+func Encode(w io.Writer, img image.Image, format string) error {
+    switch strings.ToLower(format) {
+    case "png":
+        return png.Encode(w, img)
+    case "jpg", "jpeg":
+        return jpeg.Encode(w, img, &jpeg.Options{Quality: 90})
+    }
+    return fmt.Errorf("unknown image format %q", format)
+}
+
+// WriteFiles renders entries and writes the sheets to path, its extension
+// selecting png or jpeg. When there are several sheets they are numbered, e.g.
+// `sheet-1.png` and `sheet-2.png`. The written paths are returned.
+func WriteFiles(path string, entries []Entry, config Config) ([]string, error) {
+    ext := filepath.Ext(path)
+    format := strings.TrimPrefix(ext, ".")
+    sheets := Render(entries, config)
+
+    var paths []string
+    for i, sheet := range sheets {
+        sheetPath := path
+        if len(sheets) > 1 {
+            sheetPath = fmt.Sprintf("%s-%d%s", strings.TrimSuffix(path, ext), i+1, ext)
+        }
+        file, err := os.Create(sheetPath)
+        if err != nil {
+            return paths, err
+        }
+        err = Encode(file, sheet, format)
+        if err != nil {
+            file.Close()
+            os.Remove(sheetPath)
+            return paths, err
+        }
+        err = file.Close()
+        if err != nil {
+            return paths, err
+        }
+        paths = append(paths, sheetPath)
+    }
+    return paths, nil
+}
@@ -0,0 +1,96 @@
+package contactsheet_test
+
+import (
+    "bytes"
+    "image"
+    "image/color"
+    "image/png"
+    "io/ioutil"
+    "os"
+    "path/filepath"
+    "testing"
+
+    "github.com/stretchr/testify/require"
+
+    "Azarc/contactsheet"
+)
+
+func writePoster(t *testing.T, dir string, c color.Color) string {
+    img := image.NewRGBA(image.Rect(0, 0, 20, 30))
+    for y := 0; y < 30; y++ {
+        for x := 0; x < 20; x++ {
+            img.Set(x, y, c)
+        }
+    }
+    var buf bytes.Buffer
+    require.NoError(t, png.Encode(&buf, img))
+    path := filepath.Join(dir, "poster.png")
+    require.NoError(t, ioutil.WriteFile(path, buf.Bytes(), 0644))
+    return path
+}
+
+func tempDir(t *testing.T) string {
+    dir, err := ioutil.TempDir(os.TempDir(), "contactsheet-")
+    require.NoError(t, err)
+    t.Cleanup(func() {
+        os.RemoveAll(dir)
+    })
+    return dir
+}
+
+func TestRender(t *testing.T) {
+    dir := tempDir(t)
+
+    red := color.RGBA{R: 0xff, A: 0xff}
+    poster := writePoster(t, dir, red)
+    entries := []contactsheet.Entry{
+        {PosterPath: poster, Title: "The Mask", Year: "1994"},
+        {Title: "Missing", Year: "2001"},
+        {PosterPath: filepath.Join(dir, "absent.png"), Title: "Absent", Year: "2002"},
+    }
+    config := contactsheet.Config{Columns: 2, Rows: 1, CellWidth: 100, PosterHeight: 150, Padding: 10, TextScale: 1}
+
+    sheets := contactsheet.Render(entries, config)
+    require.Len(t, sheets, 2)
+    // the first sheet fits two columns, the last one a single column
+    require.Equal(t, 2*(100+10)+10, sheets[0].Bounds().Dx())
+    require.Equal(t, 100+10+10, sheets[1].Bounds().Dx())
+
+    // the poster is scaled to 100x150 and centred in its cell
+    require.Equal(t, red, sheets[0].RGBAAt(10+50, 10+75))
+    // the missing poster is replaced by a placeholder
+    require.NotEqual(t, red, sheets[0].RGBAAt(10+110+50, 10+10))
+}
+
+func TestRender_Single(t *testing.T) {
+    entries := make([]contactsheet.Entry, 7)
+    // without Rows every entry is put on a single sheet
+    require.Len(t, contactsheet.Render(entries, contactsheet.Config{Columns: 3}), 1)
+    require.Empty(t, contactsheet.Render(nil, contactsheet.Config{}))
+
+    // a negative Padding leaves no space between the cells
+    sheets := contactsheet.Render(entries[:2], contactsheet.Config{Columns: 2, CellWidth: 100, Padding: -1})
+    require.Len(t, sheets, 1)
+    require.Equal(t, 200, sheets[0].Bounds().Dx())
+}
+
+func TestWriteFiles(t *testing.T) {
+    dir := tempDir(t)
+
+    entries := make([]contactsheet.Entry, 3)
+    config := contactsheet.Config{Columns: 1, Rows: 2, CellWidth: 20, PosterHeight: 30}
+    paths, err := contactsheet.WriteFiles(filepath.Join(dir, "sheet.jpg"), entries, config)
+    require.NoError(t, err)
+    require.Equal(t, []string{filepath.Join(dir, "sheet-1.jpg"), filepath.Join(dir, "sheet-2.jpg")}, paths)
+    for _, path := range paths {
+        file, err := os.Open(path)
+        require.NoError(t, err)
+        _, format, err := image.DecodeConfig(file)
+        file.Close()
+        require.NoError(t, err)
+        require.Equal(t, "jpeg", format, path)
+    }
+
+    _, err = contactsheet.WriteFiles(filepath.Join(dir, "sheet.bmp"), entries, config)
+    require.Error(t, err)
+}
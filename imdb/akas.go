@@ -0,0 +1,77 @@
+package imdb
+
+import (
+    "strings"
+)
+
+// Aka is a regional or alternative title as found in `title.akas.tsv`.
+type Aka struct {
+    Ordering        int
+    Title           string
+    Region          string
+    Language        string
+    Types           []string
+    Attributes      []string
+    IsOriginalTitle bool
+}
+
+// akas maps a tconst onto its alternative titles.
+type akas map[string][]Aka
+
+func (a akas) join(i *Item) {
+    i.Akas = a[i.TConst]
+}
+
+// LoadAkas reads the `title.akas.tsv(.gz)` file at path and joins the
+// alternative titles onto every Item returned by List.
+func (c *Client) LoadAkas(path string) error {
+    a := make(akas)
+    err := readTSV(path, 8, func(fields []string) error {
+        ordering, err := parseInt(fields[1])
+        if err != nil {
+            return err
+        }
+        isOriginalTitle, err := parseInt(fields[7])
+        if err != nil {
+            return err
+        }
+        a[fields[0]] = append(a[fields[0]], Aka{
+            Ordering:        ordering,
+            Title:           fields[2],
+            Region:          parseString(fields[3]),
+            Language:        parseString(fields[4]),
+            Types:           parseArray(fields[5]),
+            Attributes:      parseArray(fields[6]),
+            IsOriginalTitle: isOriginalTitle == 1,
+        })
+        return nil
+    })
+    if err != nil {
+        return err
+    }
+
+    c.akas = a
+    c.joins = append(c.joins, a)
+    return nil
+}
+
+// Akas returns the alternative titles of the title with the given tconst, if akas were loaded.
+func (c *Client) Akas(tconst string) []Aka {
+    return c.akas[tconst]
+}
+
+// parseString maps the `\N` null marker onto an empty string.
+func parseString(input string) string {
+    if input == `\N` {
+        return ""
+    }
+    return input
+}
+
+// parseArray splits the array columns of title.akas, whose values are separated by the `\x02` control character.
+func parseArray(input string) []string {
+    if input == `\N` || input == "" {
+        return nil
+    }
+    return strings.Split(input, "\x02")
+}
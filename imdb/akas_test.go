@@ -0,0 +1,76 @@
+package imdb_test
+
+import (
+    "testing"
+
+    "github.com/stretchr/testify/require"
+
+    "Azarc/imdb"
+)
+
+const akasData = "titleId\tordering\ttitle\tregion\tlanguage\ttypes\tattributes\tisOriginalTitle\n" +
+    "tt0000001\t1\tCarmencita\t\\N\t\\N\toriginal\t\\N\t1\n" +
+    "tt0000001\t2\tКарменсита\tRU\t\\N\timdbDisplay\t\\N\t0\n" +
+    "tt0033122\t1\tSwing it, Teacher!\tUS\ten\timdbDisplay\x02working\tliteral English title\t0\n" +
+    "tt0033122\t2\tSwing it magistern\tDE\t\\N\t\\N\t\\N\t0\n"
+
+func TestLoadAkas(t *testing.T) {
+    testTable := []struct {
+        name          string
+        filters       []imdb.Filter
+        expectedItems []string
+    }{
+        {
+            name:          "no filter",
+            expectedItems: []string{"tt0033122", "tt0000002", "tt0000001"},
+        },
+        {
+            name:          "region",
+            filters:       []imdb.Filter{imdb.NewAkaRegionFilter("de")},
+            expectedItems: []string{"tt0033122"},
+        },
+        {
+            name:          "title",
+            filters:       []imdb.Filter{imdb.NewAkaTitleFilter("карменсита")},
+            expectedItems: []string{"tt0000001"},
+        },
+        {
+            name:          "partial title",
+            filters:       []imdb.Filter{imdb.NewAkaTitleFilter("iT, tEACH")},
+            expectedItems: []string{"tt0033122"},
+        },
+    }
+
+    for _, test := range testTable {
+        t.Run(test.name, func(t *testing.T) {
+            imdbClient, err := imdb.New(writeTempFile(t, basicsData), 1)
+            require.NoError(t, err)
+            require.NoError(t, imdbClient.LoadAkas(writeTempFile(t, akasData)))
+
+            requireList(t, &imdbClient, test.filters, test.expectedItems)
+        })
+    }
+}
+
+func TestAkas(t *testing.T) {
+    imdbClient, err := imdb.New(writeTempFile(t, basicsData), 1)
+    require.NoError(t, err)
+    require.NoError(t, imdbClient.LoadAkas(writeTempFile(t, akasData)))
+
+    require.Equal(t, []imdb.Aka{
+        {
+            Ordering:   1,
+            Title:      "Swing it, Teacher!",
+            Region:     "US",
+            Language:   "en",
+            Types:      []string{"imdbDisplay", "working"},
+            Attributes: []string{"literal English title"},
+        },
+        {
+            Ordering: 2,
+            Title:    "Swing it magistern",
+            Region:   "DE",
+        },
+    }, imdbClient.Akas("tt0033122"))
+    require.Empty(t, imdbClient.Akas("tt0000002"))
+}
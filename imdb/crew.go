@@ -0,0 +1,45 @@
+package imdb
+
+// Crew holds the directors and writers of a title as found in `title.crew.tsv`.
+type Crew struct {
+    Directors []string
+    Writers   []string
+}
+
+// crew maps a tconst onto its directors and writers.
+type crew map[string]Crew
+
+func (c crew) join(i *Item) {
+    titleCrew, ok := c[i.TConst]
+    if !ok {
+        return
+    }
+    i.Directors = titleCrew.Directors
+    i.Writers = titleCrew.Writers
+}
+
+// LoadCrew reads the `title.crew.tsv(.gz)` file at path and joins the director
+// and writer nconsts onto every Item returned by List.
+func (c *Client) LoadCrew(path string) error {
+    titleCrew := make(crew)
+    err := readTSV(path, 3, func(fields []string) error {
+        titleCrew[fields[0]] = Crew{
+            Directors: parseList(fields[1]),
+            Writers:   parseList(fields[2]),
+        }
+        return nil
+    })
+    if err != nil {
+        return err
+    }
+
+    c.crew = titleCrew
+    c.joins = append(c.joins, titleCrew)
+    return nil
+}
+
+// Crew returns the directors and writers of the title with the given tconst, if crew was loaded.
+func (c *Client) Crew(tconst string) (Crew, bool) {
+    titleCrew, ok := c.crew[tconst]
+    return titleCrew, ok
+}
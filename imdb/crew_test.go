@@ -0,0 +1,64 @@
+package imdb_test
+
+import (
+    "testing"
+
+    "github.com/stretchr/testify/require"
+
+    "Azarc/imdb"
+)
+
+const crewData = "tconst\tdirectors\twriters\n" +
+    "tt0000001\tnm0005690\t\\N\n" +
+    "tt0000002\tnm0721526\tnm0721526\n" +
+    "tt0033122\tnm0005690,nm0721526\tnm0374658\n"
+
+func TestLoadCrew(t *testing.T) {
+    testTable := []struct {
+        name          string
+        filters       []imdb.Filter
+        expectedItems []string
+    }{
+        {
+            name:          "director",
+            filters:       []imdb.Filter{imdb.NewDirectorFilter("nm0005690")},
+            expectedItems: []string{"tt0000001", "tt0033122"},
+        },
+        {
+            name:          "writer",
+            filters:       []imdb.Filter{imdb.NewWriterFilter("nm0721526", "nm0374658")},
+            expectedItems: []string{"tt0000002", "tt0033122"},
+        },
+        {
+            name:    "unknown",
+            filters: []imdb.Filter{imdb.NewDirectorFilter("nm9999999")},
+        },
+    }
+
+    for _, test := range testTable {
+        t.Run(test.name, func(t *testing.T) {
+            imdbClient, err := imdb.New(writeTempFile(t, basicsData), 1)
+            require.NoError(t, err)
+            require.NoError(t, imdbClient.LoadCrew(writeTempFile(t, crewData)))
+
+            requireList(t, &imdbClient, test.filters, test.expectedItems)
+        })
+    }
+}
+
+func TestCrew(t *testing.T) {
+    imdbClient, err := imdb.New(writeTempFile(t, basicsData), 1)
+    require.NoError(t, err)
+    require.NoError(t, imdbClient.LoadCrew(writeTempFile(t, crewData)))
+
+    titleCrew, ok := imdbClient.Crew("tt0033122")
+    require.True(t, ok)
+    require.Equal(t, imdb.Crew{
+        Directors: []string{"nm0005690", "nm0721526"},
+        Writers:   []string{"nm0374658"},
+    }, titleCrew)
+
+    titleCrew, ok = imdbClient.Crew("tt0000001")
+    require.True(t, ok)
+    require.Nil(t, titleCrew.Writers)
+}
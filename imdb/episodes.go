@@ -0,0 +1,96 @@
+package imdb
+
+import (
+    "sort"
+)
+
+// Episode links a tvEpisode onto its parent series as found in `title.episode.tsv`.
+type Episode struct {
+    TConst        string
+    ParentTConst  string
+    SeasonNumber  int
+    EpisodeNumber int
+}
+
+// Season groups the episodes of a series sharing a season number.
+type Season struct {
+    Number   int
+    Episodes []Episode
+}
+
+// episodes maps the tconst of an episode onto its place in the parent series.
+type episodes map[string]Episode
+
+func (e episodes) join(i *Item) {
+    episode, ok := e[i.TConst]
+    if !ok {
+        return
+    }
+    i.ParentTConst = episode.ParentTConst
+    i.SeasonNumber = episode.SeasonNumber
+    i.EpisodeNumber = episode.EpisodeNumber
+}
+
+// LoadEpisodes reads the `title.episode.tsv(.gz)` file at path, joins the parent
+// series onto every episode returned by List and allows series to be expanded
+// with Seasons and Episodes.
+func (c *Client) LoadEpisodes(path string) error {
+    e := make(episodes)
+    series := make(map[string][]Episode)
+    err := readTSV(path, 4, func(fields []string) error {
+        seasonNumber, err := parseInt(fields[2])
+        if err != nil {
+            return err
+        }
+        episodeNumber, err := parseInt(fields[3])
+        if err != nil {
+            return err
+        }
+        episode := Episode{
+            TConst:        fields[0],
+            ParentTConst:  fields[1],
+            SeasonNumber:  seasonNumber,
+            EpisodeNumber: episodeNumber,
+        }
+        e[episode.TConst] = episode
+        series[episode.ParentTConst] = append(series[episode.ParentTConst], episode)
+        return nil
+    })
+    if err != nil {
+        return err
+    }
+
+    for _, list := range series {
+        sort.Slice(list, func(i, j int) bool {
+            if list[i].SeasonNumber != list[j].SeasonNumber {
+                return list[i].SeasonNumber < list[j].SeasonNumber
+            }
+            return list[i].EpisodeNumber < list[j].EpisodeNumber
+        })
+    }
+
+    c.episodes = e
+    c.series = series
+    c.joins = append(c.joins, e)
+    return nil
+}
+
+// Episodes returns the episodes of the series with the given tconst ordered by
+// season and episode number, if episodes were loaded. Episodes without a
+// season or episode number sort first.
+func (c *Client) Episodes(seriesTConst string) []Episode {
+    return c.series[seriesTConst]
+}
+
+// Seasons returns the episodes of the series with the given tconst grouped by season, ordered by season number.
+func (c *Client) Seasons(seriesTConst string) []Season {
+    var seasons []Season
+    for _, episode := range c.series[seriesTConst] {
+        if len(seasons) == 0 || seasons[len(seasons)-1].Number != episode.SeasonNumber {
+            seasons = append(seasons, Season{Number: episode.SeasonNumber})
+        }
+        last := &seasons[len(seasons)-1]
+        last.Episodes = append(last.Episodes, episode)
+    }
+    return seasons
+}
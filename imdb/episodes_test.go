@@ -0,0 +1,72 @@
+package imdb_test
+
+import (
+    "testing"
+
+    "github.com/stretchr/testify/require"
+
+    "Azarc/imdb"
+)
+
+const seriesData = "tconst\ttitleType\tprimaryTitle\toriginalTitle\tisAdult\tstartYear\tendYear\truntimeMinutes\tgenres\n" +
+    "tt0108778\ttvSeries\tFriends\tFriends\t0\t1994\t2004\t22\tComedy,Romance\n" +
+    "tt0583459\ttvEpisode\tThe One Where Monica Gets a Roommate\tThe One Where Monica Gets a Roommate\t0\t1994\t\\N\t22\tComedy,Romance\n" +
+    "tt0583647\ttvEpisode\tThe One with the Sonogram at the End\tThe One with the Sonogram at the End\t0\t1994\t\\N\t22\tComedy,Romance\n" +
+    "tt0583431\ttvEpisode\tThe One with Ross's New Girlfriend\tThe One with Ross's New Girlfriend\t0\t1995\t\\N\t22\tComedy,Romance\n"
+
+const episodesData = "tconst\tparentTconst\tseasonNumber\tepisodeNumber\n" +
+    "tt0583431\ttt0108778\t2\t1\n" +
+    "tt0583647\ttt0108778\t1\t2\n" +
+    "tt0583459\ttt0108778\t1\t1\n"
+
+func TestLoadEpisodes(t *testing.T) {
+    testTable := []struct {
+        name          string
+        filters       []imdb.Filter
+        expectedItems []string
+    }{
+        {
+            name:          "series",
+            filters:       []imdb.Filter{imdb.NewParentTConstFilter("tt0108778")},
+            expectedItems: []string{"tt0583459", "tt0583647", "tt0583431"},
+        },
+        {
+            name:          "season",
+            filters:       []imdb.Filter{imdb.NewParentTConstFilter("tt0108778"), imdb.NewSeasonNumberFilter(2)},
+            expectedItems: []string{"tt0583431"},
+        },
+    }
+
+    for _, test := range testTable {
+        t.Run(test.name, func(t *testing.T) {
+            imdbClient, err := imdb.New(writeTempFile(t, seriesData), 1)
+            require.NoError(t, err)
+            require.NoError(t, imdbClient.LoadEpisodes(writeTempFile(t, episodesData)))
+
+            requireList(t, &imdbClient, test.filters, test.expectedItems)
+        })
+    }
+}
+
+func TestSeasons(t *testing.T) {
+    imdbClient, err := imdb.New(writeTempFile(t, seriesData), 1)
+    require.NoError(t, err)
+    require.NoError(t, imdbClient.LoadEpisodes(writeTempFile(t, episodesData)))
+
+    require.Equal(t, []imdb.Season{
+        {
+            Number: 1,
+            Episodes: []imdb.Episode{
+                {TConst: "tt0583459", ParentTConst: "tt0108778", SeasonNumber: 1, EpisodeNumber: 1},
+                {TConst: "tt0583647", ParentTConst: "tt0108778", SeasonNumber: 1, EpisodeNumber: 2},
+            },
+        },
+        {
+            Number: 2,
+            Episodes: []imdb.Episode{
+                {TConst: "tt0583431", ParentTConst: "tt0108778", SeasonNumber: 2, EpisodeNumber: 1},
+            },
+        },
+    }, imdbClient.Seasons("tt0108778"))
+    require.Empty(t, imdbClient.Seasons("tt0583431"))
+}
@@ -1,5 +1,8 @@
 package imdb
 
+import (
+    "strings"
+)
 
 type titleType string
 
@@ -97,3 +100,137 @@ func (f genres) filter(i Item) bool {
 func NewGenresFilter(s []string) Filter {
     return genres(s)
 }
+
+type minRating float64
+
+func (f minRating) filter(i Item) bool {
+    return i.AverageRating >= float64(f)
+}
+
+// NewMinRatingFilter keeps titles with an averageRating of at least f. Requires Client.LoadRatings.
+func NewMinRatingFilter(f float64) Filter {
+    return minRating(f)
+}
+
+type minVotes int
+
+func (f minVotes) filter(i Item) bool {
+    return i.NumVotes >= int(f)
+}
+
+// NewMinVotesFilter keeps titles with at least i votes. Requires Client.LoadRatings.
+func NewMinVotesFilter(i int) Filter {
+    return minVotes(i)
+}
+
+type akaRegion string
+
+func (f akaRegion) filter(i Item) bool {
+    for _, aka := range i.Akas {
+        if strings.EqualFold(aka.Region, string(f)) {
+            return true
+        }
+    }
+    return false
+}
+
+// NewAkaRegionFilter keeps titles with an alternative title in region s, e.g. `DE`. Requires Client.LoadAkas.
+func NewAkaRegionFilter(s string) Filter {
+    return akaRegion(s)
+}
+
+type akaTitle string
+
+func (f akaTitle) filter(i Item) bool {
+    for _, aka := range i.Akas {
+        if strings.Contains(strings.ToLower(aka.Title), strings.ToLower(string(f))) {
+            return true
+        }
+    }
+    return false
+}
+
+// NewAkaTitleFilter keeps titles with an alternative title containing s, ignoring case. Requires Client.LoadAkas.
+func NewAkaTitleFilter(s string) Filter {
+    return akaTitle(s)
+}
+
+type principal struct {
+    category string
+    nconsts  []string
+}
+
+func (f principal) filter(i Item) bool {
+    for _, p := range i.Principals {
+        if f.category != "" && p.Category != f.category {
+            continue
+        }
+        for _, nconst := range f.nconsts {
+            if p.NConst == nconst {
+                return true
+            }
+        }
+    }
+    return false
+}
+
+// NewPrincipalFilter keeps titles crediting any of nconsts as a principal in category, e.g. `actor`.
+// An empty category matches any category. Requires Client.LoadPrincipals.
+func NewPrincipalFilter(category string, nconsts ...string) Filter {
+    return principal{category: category, nconsts: nconsts}
+}
+
+type parentTConst string
+
+func (f parentTConst) filter(i Item) bool {
+    return i.ParentTConst == string(f)
+}
+
+// NewParentTConstFilter keeps the episodes of the series with tconst s. Requires Client.LoadEpisodes.
+func NewParentTConstFilter(s string) Filter {
+    return parentTConst(s)
+}
+
+type seasonNumber int
+
+func (f seasonNumber) filter(i Item) bool {
+    return i.SeasonNumber == int(f)
+}
+
+// NewSeasonNumberFilter keeps the episodes of season i. Requires Client.LoadEpisodes.
+func NewSeasonNumberFilter(i int) Filter {
+    return seasonNumber(i)
+}
+
+type director []string
+
+func (f director) filter(i Item) bool {
+    return containsAny(i.Directors, f)
+}
+
+// NewDirectorFilter keeps titles directed by any of nconsts. Requires Client.LoadCrew.
+func NewDirectorFilter(nconsts ...string) Filter {
+    return director(nconsts)
+}
+
+type writer []string
+
+func (f writer) filter(i Item) bool {
+    return containsAny(i.Writers, f)
+}
+
+// NewWriterFilter keeps titles written by any of nconsts. Requires Client.LoadCrew.
+func NewWriterFilter(nconsts ...string) Filter {
+    return writer(nconsts)
+}
+
+func containsAny(list []string, values []string) bool {
+    for _, item := range list {
+        for _, value := range values {
+            if item == value {
+                return true
+            }
+        }
+    }
+    return false
+}
@@ -0,0 +1,139 @@
+package imdb
+
+import (
+    "errors"
+)
+
+var ErrNoPath = errors.New("no path between people")
+
+// Graph links people and the titles they share, built from the principals joined onto a list of Items.
+type Graph struct {
+    titles  map[string]Item
+    credits map[string][]string
+    people  map[string][]string
+}
+
+// Path is a chain of people where Titles[i] is shared by People[i] and People[i+1].
+type Path struct {
+    People []string
+    Titles []string
+}
+
+// NewGraph builds a Graph from items returned by List. Only the titles in
+// items are part of the graph, so filter the List call to restrict the graph,
+// e.g. to movies only. Requires Client.LoadPrincipals.
+func NewGraph(items []Item) *Graph {
+    g := &Graph{
+        titles:  make(map[string]Item, len(items)),
+        credits: make(map[string][]string, len(items)),
+        people:  make(map[string][]string),
+    }
+    for _, item := range items {
+        g.titles[item.TConst] = item
+        seen := make(map[string]bool, len(item.Principals))
+        for _, p := range item.Principals {
+            if seen[p.NConst] {
+                continue
+            }
+            seen[p.NConst] = true
+            g.credits[item.TConst] = append(g.credits[item.TConst], p.NConst)
+            g.people[p.NConst] = append(g.people[p.NConst], item.TConst)
+        }
+    }
+    return g
+}
+
+// Title returns the Item with the given tconst, if it is part of the graph.
+func (g *Graph) Title(tconst string) (Item, bool) {
+    item, ok := g.titles[tconst]
+    return item, ok
+}
+
+// link records how a person was reached during a search.
+type link struct {
+    person string
+    title  string
+    depth  int
+}
+
+// ShortestPath returns the shortest chain of shared titles between the people
+// with nconsts from and to, searching from both ends at once.
+func (g *Graph) ShortestPath(from, to string) (Path, error) {
+    if _, ok := g.people[from]; !ok {
+        return Path{}, ErrNoPath
+    }
+    if _, ok := g.people[to]; !ok {
+        return Path{}, ErrNoPath
+    }
+    if from == to {
+        return Path{People: []string{from}}, nil
+    }
+
+    forward := map[string]link{from: {}}
+    backward := map[string]link{to: {}}
+    forwardFrontier := []string{from}
+    backwardFrontier := []string{to}
+
+    for len(forwardFrontier) > 0 && len(backwardFrontier) > 0 {
+        var meet string
+        if len(forwardFrontier) <= len(backwardFrontier) {
+            forwardFrontier, meet = g.expand(forwardFrontier, forward, backward)
+        } else {
+            backwardFrontier, meet = g.expand(backwardFrontier, backward, forward)
+        }
+        if meet != "" {
+            return buildPath(meet, forward, backward), nil
+        }
+    }
+    return Path{}, ErrNoPath
+}
+
+// expand visits every person sharing a title with the frontier. It returns the
+// next frontier and, when the other search was reached, the meeting person
+// giving the shortest total path.
+func (g *Graph) expand(frontier []string, visited, other map[string]link) ([]string, string) {
+    var next []string
+    meet := ""
+    best := 0
+    for _, person := range frontier {
+        depth := visited[person].depth + 1
+        for _, title := range g.people[person] {
+            for _, costar := range g.credits[title] {
+                if _, ok := visited[costar]; ok {
+                    continue
+                }
+                visited[costar] = link{person: person, title: title, depth: depth}
+                next = append(next, costar)
+
+                if l, ok := other[costar]; ok && (meet == "" || depth+l.depth < best) {
+                    meet = costar
+                    best = depth + l.depth
+                }
+            }
+        }
+    }
+    return next, meet
+}
+
+func buildPath(meet string, forward, backward map[string]link) Path {
+    var path Path
+    for person := meet; ; {
+        path.People = append([]string{person}, path.People...)
+        l := forward[person]
+        if l.person == "" {
+            break
+        }
+        path.Titles = append([]string{l.title}, path.Titles...)
+        person = l.person
+    }
+    for person := meet; ; {
+        l := backward[person]
+        if l.person == "" {
+            break
+        }
+        path.Titles = append(path.Titles, l.title)
+        path.People = append(path.People, l.person)
+        person = l.person
+    }
+    return path
+}
@@ -0,0 +1,91 @@
+package imdb_test
+
+import (
+    "testing"
+
+    "github.com/stretchr/testify/require"
+
+    "Azarc/imdb"
+)
+
+func graphItem(tconst string, nconsts ...string) imdb.Item {
+    item := imdb.Item{TConst: tconst, TitleType: "movie"}
+    for i, nconst := range nconsts {
+        item.Principals = append(item.Principals, imdb.Principal{NConst: nconst, Ordering: i + 1, Category: "actor"})
+    }
+    return item
+}
+
+func TestShortestPath(t *testing.T) {
+    graph := imdb.NewGraph([]imdb.Item{
+        graphItem("tt1", "nmA", "nmB"),
+        graphItem("tt2", "nmB", "nmC"),
+        graphItem("tt3", "nmC", "nmD"),
+        graphItem("tt4", "nmD", "nmE"),
+        graphItem("tt5", "nmA", "nmF"),
+        graphItem("tt6", "nmF", "nmD"),
+        graphItem("tt7", "nmX", "nmY"),
+    })
+
+    testTable := []struct {
+        name         string
+        from         string
+        to           string
+        expectedPath imdb.Path
+        expectedErr  error
+    }{
+        {
+            name:         "same person",
+            from:         "nmA",
+            to:           "nmA",
+            expectedPath: imdb.Path{People: []string{"nmA"}},
+        },
+        {
+            name: "direct",
+            from: "nmA",
+            to:   "nmB",
+            expectedPath: imdb.Path{
+                People: []string{"nmA", "nmB"},
+                Titles: []string{"tt1"},
+            },
+        },
+        {
+            name: "shortcut",
+            from: "nmA",
+            to:   "nmE",
+            expectedPath: imdb.Path{
+                People: []string{"nmA", "nmF", "nmD", "nmE"},
+                Titles: []string{"tt5", "tt6", "tt4"},
+            },
+        },
+        {
+            name: "reversed",
+            from: "nmE",
+            to:   "nmB",
+            expectedPath: imdb.Path{
+                People: []string{"nmE", "nmD", "nmC", "nmB"},
+                Titles: []string{"tt4", "tt3", "tt2"},
+            },
+        },
+        {
+            name:        "disconnected",
+            from:        "nmA",
+            to:          "nmX",
+            expectedErr: imdb.ErrNoPath,
+        },
+        {
+            name:        "unknown person",
+            from:        "nmA",
+            to:          "nmZ",
+            expectedErr: imdb.ErrNoPath,
+        },
+    }
+
+    for _, test := range testTable {
+        t.Run(test.name, func(t *testing.T) {
+            path, err := graph.ShortestPath(test.from, test.to)
+            require.Equal(t, test.expectedErr, err)
+            require.Equal(t, test.expectedPath, path)
+        })
+    }
+}
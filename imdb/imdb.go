@@ -1,11 +1,9 @@
 package imdb
 
 import (
-    "bufio"
     "context"
     "errors"
     "fmt"
-    "os"
     "strconv"
     "strings"
     "sync"
@@ -21,21 +19,38 @@ type Item struct {
     EndYear        int
     RuntimeMinutes int
     Genres         []string
+    AverageRating  float64
+    NumVotes       int
+    Akas           []Aka
+    Principals     []Principal
+    ParentTConst   string
+    SeasonNumber   int
+    EpisodeNumber  int
+    Directors      []string
+    Writers        []string
 }
 
 type Client struct {
     path       string
     goroutines int
+    joins      []joiner
+    ratings    ratings
+    akas       akas
+    people     map[string]Person
+    principals principals
+    episodes   episodes
+    series     map[string][]Episode
+    crew       crew
 }
 
 func New(path string, goroutines int) (Client, error) {
-    file, err := os.Open(path)
+    file, err := openFile(path)
     if err != nil {
         return Client{}, err
     }
     defer file.Close()
 
-    scanner := bufio.NewScanner(file)
+    scanner := newScanner(file)
     ok := scanner.Scan()
     if !ok {
         return Client{}, scanner.Err()
@@ -59,6 +74,11 @@ type Filter interface {
     filter(i Item) bool
 }
 
+// interface joiner attaches data from a secondary dataset onto an Item before it is filtered
+type joiner interface {
+    join(i *Item)
+}
+
 func (c *Client) List(ctx context.Context, filters ...Filter) ([]Item, error) {
     errorChan := make(chan error)
     wgDone := make(chan bool)
@@ -100,13 +120,13 @@ func (c *Client) List(ctx context.Context, filters ...Filter) ([]Item, error) {
 }
 
 func (c *Client) list(ctx context.Context, offset int, out *[]Item, filters []Filter) error {
-    file, err := os.Open(c.path)
+    file, err := openFile(c.path)
     if err != nil {
         return err
     }
     defer file.Close()
 
-    scanner := bufio.NewScanner(file)
+    scanner := newScanner(file)
     for i := 0; i < offset+1; i++ {
         if !scanner.Scan(){
             return scanner.Err()
@@ -120,7 +140,7 @@ func (c *Client) list(ctx context.Context, offset int, out *[]Item, filters []Fi
         default:
         }
 
-        done, err := scan(scanner, out, filters)
+        done, err := scan(scanner, out, c.joins, filters)
         if err != nil {
             return err
         }
@@ -134,8 +154,6 @@ func (c *Client) list(ctx context.Context, offset int, out *[]Item, filters []Fi
             }
         }
     }
-
-    return nil
 }
 
 type scanner interface {
@@ -144,7 +162,7 @@ type scanner interface {
     Text() string
 }
 
-func scan(scanner scanner, out *[]Item, filters []Filter) (bool, error) {
+func scan(scanner scanner, out *[]Item, joins []joiner, filters []Filter) (bool, error) {
     if !scanner.Scan() {
         if scanner.Err() != nil {
             return false, scanner.Err()
@@ -186,6 +204,10 @@ func scan(scanner scanner, out *[]Item, filters []Filter) (bool, error) {
         Genres:         genres,
     }
 
+    for _, joiner := range joins {
+        joiner.join(&item)
+    }
+
     for _, filter := range filters {
         if !filter.filter(item) {
             return  false, nil
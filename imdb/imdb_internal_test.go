@@ -142,7 +142,7 @@ func TestScan(t *testing.T) {
         t.Run(test.name, func(t *testing.T) {
             var out []Item
             out = append(out, test.out...)
-            resp, err := scan(&test.scanner, &out, test.filters)
+            resp, err := scan(&test.scanner, &out, nil, test.filters)
             require.NoError(t, err)
             require.ElementsMatch(t, test.expectedOut, out)
             require.Equal(t, test.expectedDone, resp)
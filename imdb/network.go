@@ -0,0 +1,188 @@
+package imdb
+
+import (
+    "bufio"
+    "encoding/xml"
+    "fmt"
+    "io"
+    "sort"
+    "strings"
+)
+
+// Edge links two people, Weight being the number of titles they share.
+type Edge struct {
+    From   string
+    To     string
+    Weight int
+}
+
+// Network is a person to person co-appearance graph.
+type Network struct {
+    People []string
+    Edges  []Edge
+}
+
+// Network returns the co-appearance graph of every person in the graph,
+// dropping edges sharing fewer than minWeight titles.
+func (g *Graph) Network(minWeight int) Network {
+    people := make(map[string]bool, len(g.people))
+    for nconst := range g.people {
+        people[nconst] = true
+    }
+    return g.network(people, minWeight)
+}
+
+// CoStars returns the co-appearance graph of the people within depth shared
+// titles of root. Only edges sharing at least minWeight titles are followed.
+func (g *Graph) CoStars(root string, depth int, minWeight int) Network {
+    if _, ok := g.people[root]; !ok {
+        return Network{}
+    }
+
+    people := map[string]bool{root: true}
+    frontier := []string{root}
+    for i := 0; i < depth && len(frontier) > 0; i++ {
+        var next []string
+        for _, person := range frontier {
+            for costar, weight := range g.costars(person) {
+                if people[costar] || weight < minWeight {
+                    continue
+                }
+                people[costar] = true
+                next = append(next, costar)
+            }
+        }
+        frontier = next
+    }
+    return g.network(people, minWeight)
+}
+
+// costars counts the titles person shares with every other person.
+func (g *Graph) costars(person string) map[string]int {
+    weights := make(map[string]int)
+    for _, title := range g.people[person] {
+        for _, costar := range g.credits[title] {
+            if costar != person {
+                weights[costar]++
+            }
+        }
+    }
+    return weights
+}
+
+// network builds the edges between the given people, ordered for stable output.
+func (g *Graph) network(people map[string]bool, minWeight int) Network {
+    var n Network
+    for person := range people {
+        n.People = append(n.People, person)
+        for costar, weight := range g.costars(person) {
+            if person < costar && people[costar] && weight >= minWeight {
+                n.Edges = append(n.Edges, Edge{From: person, To: costar, Weight: weight})
+            }
+        }
+    }
+
+    sort.Strings(n.People)
+    sort.Slice(n.Edges, func(i, j int) bool {
+        if n.Edges[i].From != n.Edges[j].From {
+            return n.Edges[i].From < n.Edges[j].From
+        }
+        return n.Edges[i].To < n.Edges[j].To
+    })
+    return n
+}
+
+// WriteDOT writes the network as an undirected Graphviz graph. label names
+// every person, e.g. by looking up the primaryName with Client.Person.
+func (n Network) WriteDOT(w io.Writer, label func(nconst string) string) error {
+    b := bufio.NewWriter(w)
+    fmt.Fprintf(b, "graph costars {\n")
+    for _, person := range n.People {
+        fmt.Fprintf(b, "    %s [label=%s];\n", dotQuote(person), dotQuote(label(person)))
+    }
+    for _, edge := range n.Edges {
+        fmt.Fprintf(b, "    %s -- %s [weight=%d, label=\"%d\"];\n", dotQuote(edge.From), dotQuote(edge.To), edge.Weight, edge.Weight)
+    }
+    fmt.Fprintf(b, "}\n")
+    return b.Flush()
+}
+
+func dotQuote(s string) string {
+    return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+type graphML struct {
+    XMLName xml.Name     `xml:"graphml"`
+    XMLNS   string       `xml:"xmlns,attr"`
+    Keys    []graphMLKey `xml:"key"`
+    Graph   graphMLGraph `xml:"graph"`
+}
+
+type graphMLKey struct {
+    ID       string `xml:"id,attr"`
+    For      string `xml:"for,attr"`
+    AttrName string `xml:"attr.name,attr"`
+    AttrType string `xml:"attr.type,attr"`
+}
+
+type graphMLGraph struct {
+    ID          string        `xml:"id,attr"`
+    EdgeDefault string        `xml:"edgedefault,attr"`
+    Nodes       []graphMLNode `xml:"node"`
+    Edges       []graphMLEdge `xml:"edge"`
+}
+
+type graphMLNode struct {
+    ID   string        `xml:"id,attr"`
+    Data []graphMLData `xml:"data"`
+}
+
+type graphMLEdge struct {
+    Source string        `xml:"source,attr"`
+    Target string        `xml:"target,attr"`
+    Data   []graphMLData `xml:"data"`
+}
+
+type graphMLData struct {
+    Key   string `xml:"key,attr"`
+    Value string `xml:",chardata"`
+}
+
+// WriteGraphML writes the network as an undirected GraphML document. label
+// names every person, e.g. by looking up the primaryName with Client.Person.
+func (n Network) WriteGraphML(w io.Writer, label func(nconst string) string) error {
+    doc := graphML{
+        XMLNS: "http://graphml.graphdrawing.org/xmlns",
+        Keys: []graphMLKey{
+            {ID: "label", For: "node", AttrName: "label", AttrType: "string"},
+            {ID: "weight", For: "edge", AttrName: "weight", AttrType: "int"},
+        },
+        Graph: graphMLGraph{ID: "costars", EdgeDefault: "undirected"},
+    }
+    for _, person := range n.People {
+        doc.Graph.Nodes = append(doc.Graph.Nodes, graphMLNode{
+            ID:   person,
+            Data: []graphMLData{{Key: "label", Value: label(person)}},
+        })
+    }
+    for _, edge := range n.Edges {
+        doc.Graph.Edges = append(doc.Graph.Edges, graphMLEdge{
+            Source: edge.From,
+            Target: edge.To,
+            Data:   []graphMLData{{Key: "weight", Value: fmt.Sprint(edge.Weight)}},
+        })
+    }
+
+    _, err := io.WriteString(w, xml.Header)
+    if err != nil {
+        return err
+    }
+    encoder := xml.NewEncoder(w)
+    encoder.Indent("", "  ")
+    err = encoder.Encode(doc)
+    if err != nil {
+        return err
+    }
+    _, err = io.WriteString(w, "\n")
+    return err
+}
@@ -0,0 +1,123 @@
+package imdb_test
+
+import (
+    "bytes"
+    "encoding/xml"
+    "strings"
+    "testing"
+
+    "github.com/stretchr/testify/require"
+
+    "Azarc/imdb"
+)
+
+func TestCoStars(t *testing.T) {
+    graph := imdb.NewGraph([]imdb.Item{
+        graphItem("tt1", "nmA", "nmB"),
+        graphItem("tt2", "nmA", "nmB", "nmC"),
+        graphItem("tt3", "nmC", "nmD"),
+        graphItem("tt4", "nmD", "nmE"),
+    })
+
+    testTable := []struct {
+        name            string
+        root            string
+        depth           int
+        minWeight       int
+        expectedNetwork imdb.Network
+    }{
+        {
+            name:      "depth one",
+            root:      "nmA",
+            depth:     1,
+            minWeight: 1,
+            expectedNetwork: imdb.Network{
+                People: []string{"nmA", "nmB", "nmC"},
+                Edges: []imdb.Edge{
+                    {From: "nmA", To: "nmB", Weight: 2},
+                    {From: "nmA", To: "nmC", Weight: 1},
+                    {From: "nmB", To: "nmC", Weight: 1},
+                },
+            },
+        },
+        {
+            name:      "depth two",
+            root:      "nmA",
+            depth:     2,
+            minWeight: 1,
+            expectedNetwork: imdb.Network{
+                People: []string{"nmA", "nmB", "nmC", "nmD"},
+                Edges: []imdb.Edge{
+                    {From: "nmA", To: "nmB", Weight: 2},
+                    {From: "nmA", To: "nmC", Weight: 1},
+                    {From: "nmB", To: "nmC", Weight: 1},
+                    {From: "nmC", To: "nmD", Weight: 1},
+                },
+            },
+        },
+        {
+            name:      "min weight",
+            root:      "nmA",
+            depth:     3,
+            minWeight: 2,
+            expectedNetwork: imdb.Network{
+                People: []string{"nmA", "nmB"},
+                Edges: []imdb.Edge{
+                    {From: "nmA", To: "nmB", Weight: 2},
+                },
+            },
+        },
+        {
+            name:  "unknown person",
+            root:  "nmZ",
+            depth: 1,
+        },
+    }
+
+    for _, test := range testTable {
+        t.Run(test.name, func(t *testing.T) {
+            require.Equal(t, test.expectedNetwork, graph.CoStars(test.root, test.depth, test.minWeight))
+        })
+    }
+}
+
+func TestNetwork_Write(t *testing.T) {
+    network := imdb.NewGraph([]imdb.Item{
+        graphItem("tt1", "nmA", "nmB"),
+        graphItem("tt2", "nmA", "nmB"),
+    }).Network(1)
+    label := func(nconst string) string {
+        return strings.TrimPrefix(nconst, "nm") + ` "quoted"`
+    }
+
+    var dot bytes.Buffer
+    require.NoError(t, network.WriteDOT(&dot, label))
+    require.Equal(t, "graph costars {\n"+
+        "    \"nmA\" [label=\"A \\\"quoted\\\"\"];\n"+
+        "    \"nmB\" [label=\"B \\\"quoted\\\"\"];\n"+
+        "    \"nmA\" -- \"nmB\" [weight=2, label=\"2\"];\n"+
+        "}\n", dot.String())
+
+    var graphML bytes.Buffer
+    require.NoError(t, network.WriteGraphML(&graphML, label))
+
+    var doc struct {
+        Nodes []struct {
+            ID   string `xml:"id,attr"`
+            Data string `xml:"data"`
+        } `xml:"graph>node"`
+        Edges []struct {
+            Source string `xml:"source,attr"`
+            Target string `xml:"target,attr"`
+            Data   string `xml:"data"`
+        } `xml:"graph>edge"`
+    }
+    require.NoError(t, xml.Unmarshal(graphML.Bytes(), &doc))
+    require.Len(t, doc.Nodes, 2)
+    require.Equal(t, "nmA", doc.Nodes[0].ID)
+    require.Equal(t, `A "quoted"`, doc.Nodes[0].Data)
+    require.Len(t, doc.Edges, 1)
+    require.Equal(t, "nmA", doc.Edges[0].Source)
+    require.Equal(t, "nmB", doc.Edges[0].Target)
+    require.Equal(t, "2", doc.Edges[0].Data)
+}
@@ -0,0 +1,126 @@
+package imdb
+
+import (
+    "encoding/json"
+    "strings"
+)
+
+// Person is a cast or crew member as found in `name.basics.tsv`.
+type Person struct {
+    NConst            string
+    PrimaryName       string
+    BirthYear         int
+    DeathYear         int
+    PrimaryProfession []string
+    KnownForTitles    []string
+}
+
+// Principal is the credit of a person on a title as found in `title.principals.tsv`.
+type Principal struct {
+    NConst     string
+    Ordering   int
+    Category   string
+    Job        string
+    Characters []string
+}
+
+// principals maps a tconst onto its principal cast and crew.
+type principals map[string][]Principal
+
+func (p principals) join(i *Item) {
+    i.Principals = p[i.TConst]
+}
+
+// LoadNames reads the `name.basics.tsv(.gz)` file at path so people can be looked up with Person and PeopleByName.
+func (c *Client) LoadNames(path string) error {
+    people := make(map[string]Person)
+    err := readTSV(path, 6, func(fields []string) error {
+        birthYear, err := parseInt(fields[2])
+        if err != nil {
+            return err
+        }
+        deathYear, err := parseInt(fields[3])
+        if err != nil {
+            return err
+        }
+        people[fields[0]] = Person{
+            NConst:            fields[0],
+            PrimaryName:       fields[1],
+            BirthYear:         birthYear,
+            DeathYear:         deathYear,
+            PrimaryProfession: parseList(fields[4]),
+            KnownForTitles:    parseList(fields[5]),
+        }
+        return nil
+    })
+    if err != nil {
+        return err
+    }
+
+    c.people = people
+    return nil
+}
+
+// LoadPrincipals reads the `title.principals.tsv(.gz)` file at path and joins
+// the principal cast and crew onto every Item returned by List.
+func (c *Client) LoadPrincipals(path string) error {
+    p := make(principals)
+    err := readTSV(path, 6, func(fields []string) error {
+        ordering, err := parseInt(fields[1])
+        if err != nil {
+            return err
+        }
+        var characters []string
+        if fields[5] != `\N` {
+            err = json.Unmarshal([]byte(fields[5]), &characters)
+            if err != nil {
+                return err
+            }
+        }
+        p[fields[0]] = append(p[fields[0]], Principal{
+            NConst:     fields[2],
+            Ordering:   ordering,
+            Category:   fields[3],
+            Job:        parseString(fields[4]),
+            Characters: characters,
+        })
+        return nil
+    })
+    if err != nil {
+        return err
+    }
+
+    c.principals = p
+    c.joins = append(c.joins, p)
+    return nil
+}
+
+// Person returns the person with the given nconst, if names were loaded.
+func (c *Client) Person(nconst string) (Person, bool) {
+    person, ok := c.people[nconst]
+    return person, ok
+}
+
+// PeopleByName returns every person whose primaryName equals name, ignoring case, if names were loaded.
+func (c *Client) PeopleByName(name string) []Person {
+    var people []Person
+    for _, person := range c.people {
+        if strings.EqualFold(person.PrimaryName, name) {
+            people = append(people, person)
+        }
+    }
+    return people
+}
+
+// Principals returns the principal cast and crew of the title with the given tconst, if principals were loaded.
+func (c *Client) Principals(tconst string) []Principal {
+    return c.principals[tconst]
+}
+
+// parseList splits a comma separated column, mapping `\N` to an empty list.
+func parseList(input string) []string {
+    if input == `\N` || input == "" {
+        return nil
+    }
+    return strings.Split(input, ",")
+}
@@ -0,0 +1,93 @@
+package imdb_test
+
+import (
+    "testing"
+
+    "github.com/stretchr/testify/require"
+
+    "Azarc/imdb"
+)
+
+const namesData = "nconst\tprimaryName\tbirthYear\tdeathYear\tprimaryProfession\tknownForTitles\n" +
+    "nm0000001\tFred Astaire\t1899\t1987\tsoundtrack,actor,miscellaneous\ttt0033122,tt0000001\n" +
+    "nm0000002\tLauren Bacall\t1924\t2014\tactress,soundtrack\t\\N\n" +
+    "nm0000003\tFred Astaire\t\\N\t\\N\t\\N\t\\N\n"
+
+const principalsData = "tconst\tordering\tnconst\tcategory\tjob\tcharacters\n" +
+    "tt0000001\t1\tnm0000001\tactor\t\\N\t[\"Self\"]\n" +
+    "tt0000001\t2\tnm0000002\tdirector\t\\N\t\\N\n" +
+    "tt0033122\t1\tnm0000002\tactress\t\\N\t[\"Inga\",\"Herself\"]\n" +
+    "tt0000002\t1\tnm0000003\tcinematographer\tdirector of photography\t\\N\n"
+
+func TestLoadPrincipals(t *testing.T) {
+    testTable := []struct {
+        name          string
+        filters       []imdb.Filter
+        expectedItems []string
+    }{
+        {
+            name:          "any category",
+            filters:       []imdb.Filter{imdb.NewPrincipalFilter("", "nm0000002")},
+            expectedItems: []string{"tt0000001", "tt0033122"},
+        },
+        {
+            name:          "category",
+            filters:       []imdb.Filter{imdb.NewPrincipalFilter("actress", "nm0000002")},
+            expectedItems: []string{"tt0033122"},
+        },
+        {
+            name:          "many people",
+            filters:       []imdb.Filter{imdb.NewPrincipalFilter("", "nm0000001", "nm0000003")},
+            expectedItems: []string{"tt0000001", "tt0000002"},
+        },
+    }
+
+    for _, test := range testTable {
+        t.Run(test.name, func(t *testing.T) {
+            imdbClient, err := imdb.New(writeTempFile(t, basicsData), 1)
+            require.NoError(t, err)
+            require.NoError(t, imdbClient.LoadPrincipals(writeTempFile(t, principalsData)))
+
+            requireList(t, &imdbClient, test.filters, test.expectedItems)
+        })
+    }
+}
+
+func TestPrincipals(t *testing.T) {
+    imdbClient, err := imdb.New(writeTempFile(t, basicsData), 1)
+    require.NoError(t, err)
+    require.NoError(t, imdbClient.LoadPrincipals(writeTempFile(t, principalsData)))
+
+    require.Equal(t, []imdb.Principal{{
+        NConst:     "nm0000002",
+        Ordering:   1,
+        Category:   "actress",
+        Characters: []string{"Inga", "Herself"},
+    }}, imdbClient.Principals("tt0033122"))
+}
+
+func TestPeople(t *testing.T) {
+    imdbClient, err := imdb.New(writeTempFile(t, basicsData), 1)
+    require.NoError(t, err)
+    require.NoError(t, imdbClient.LoadNames(writeTempFile(t, namesData)))
+
+    person, ok := imdbClient.Person("nm0000001")
+    require.True(t, ok)
+    require.Equal(t, imdb.Person{
+        NConst:            "nm0000001",
+        PrimaryName:       "Fred Astaire",
+        BirthYear:         1899,
+        DeathYear:         1987,
+        PrimaryProfession: []string{"soundtrack", "actor", "miscellaneous"},
+        KnownForTitles:    []string{"tt0033122", "tt0000001"},
+    }, person)
+
+    _, ok = imdbClient.Person("nm9999999")
+    require.False(t, ok)
+
+    var nconsts []string
+    for _, p := range imdbClient.PeopleByName("fred astaire") {
+        nconsts = append(nconsts, p.NConst)
+    }
+    require.ElementsMatch(t, []string{"nm0000001", "nm0000003"}, nconsts)
+}
@@ -0,0 +1,57 @@
+package imdb
+
+import (
+    "strconv"
+)
+
+// Rating holds the IMDb user rating of a title as found in `title.ratings.tsv`.
+type Rating struct {
+    AverageRating float64
+    NumVotes      int
+}
+
+// ratings maps a tconst onto its rating.
+type ratings map[string]Rating
+
+func (r ratings) join(i *Item) {
+    rating, ok := r[i.TConst]
+    if !ok {
+        return
+    }
+    i.AverageRating = rating.AverageRating
+    i.NumVotes = rating.NumVotes
+}
+
+// LoadRatings reads the `title.ratings.tsv(.gz)` file at path and joins its
+// averageRating and numVotes columns onto every Item returned by List.
+func (c *Client) LoadRatings(path string) error {
+    r := make(ratings)
+    err := readTSV(path, 3, func(fields []string) error {
+        averageRating, err := strconv.ParseFloat(fields[1], 64)
+        if err != nil {
+            return err
+        }
+        numVotes, err := parseInt(fields[2])
+        if err != nil {
+            return err
+        }
+        r[fields[0]] = Rating{
+            AverageRating: averageRating,
+            NumVotes:      numVotes,
+        }
+        return nil
+    })
+    if err != nil {
+        return err
+    }
+
+    c.ratings = r
+    c.joins = append(c.joins, r)
+    return nil
+}
+
+// Rating returns the rating of the title with the given tconst, if ratings were loaded.
+func (c *Client) Rating(tconst string) (Rating, bool) {
+    rating, ok := c.ratings[tconst]
+    return rating, ok
+}
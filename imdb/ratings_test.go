@@ -0,0 +1,120 @@
+package imdb_test
+
+import (
+    "compress/gzip"
+    "context"
+    "io/ioutil"
+    "os"
+    "testing"
+
+    "github.com/stretchr/testify/require"
+
+    "Azarc/imdb"
+)
+
+const basicsData = "tconst\ttitleType\tprimaryTitle\toriginalTitle\tisAdult\tstartYear\tendYear\truntimeMinutes\tgenres\n" +
+    "tt0033122\tmovie\t\"Swing it\" magistern\t\"Swing it\" magistern\t0\t1940\t\\N\t92\tComedy,Music\n" +
+    "tt0000002\tshort\tLe clown et ses chiens\tLe clown et ses chiens\t\\N\t\\N\t\\N\t\\N\tAnimation,Short\n" +
+    "tt0000001\tshort\tCarmencita\tCarmencita\t0\t1894\t2020\t1\tDocumentary,Short\n"
+
+func writeTempFile(t *testing.T, data string) string {
+    file, err := ioutil.TempFile(os.TempDir(), "testIMDBFile-")
+    require.NoError(t, err)
+    _, err = file.Write([]byte(data))
+    require.NoError(t, err)
+    require.NoError(t, file.Close())
+    t.Cleanup(func() {
+        os.Remove(file.Name())
+    })
+    return file.Name()
+}
+
+func writeTempGzipFile(t *testing.T, data string) string {
+    file, err := ioutil.TempFile(os.TempDir(), "testIMDBFile-*.tsv.gz")
+    require.NoError(t, err)
+    writer := gzip.NewWriter(file)
+    _, err = writer.Write([]byte(data))
+    require.NoError(t, err)
+    require.NoError(t, writer.Close())
+    require.NoError(t, file.Close())
+    t.Cleanup(func() {
+        os.Remove(file.Name())
+    })
+    return file.Name()
+}
+
+// requireList lists the items of imdbClient matching filters, requiring their tconsts to match expected.
+func requireList(t *testing.T, imdbClient *imdb.Client, filters []imdb.Filter, expected []string) []imdb.Item {
+    resp, err := imdbClient.List(context.Background(), filters...)
+    require.NoError(t, err)
+
+    var tconsts []string
+    for _, item := range resp {
+        tconsts = append(tconsts, item.TConst)
+    }
+    require.ElementsMatch(t, expected, tconsts)
+    return resp
+}
+
+func TestLoadRatings(t *testing.T) {
+    ratingsData := "tconst\taverageRating\tnumVotes\n" +
+        "tt0000001\t5.7\t1845\n" +
+        "tt0033122\t6.9\t120\n"
+
+    testTable := []struct {
+        name          string
+        ratingsPath   string
+        filters       []imdb.Filter
+        expectedItems []string
+    }{
+        {
+            name:          "no filter",
+            ratingsPath:   writeTempFile(t, ratingsData),
+            expectedItems: []string{"tt0033122", "tt0000002", "tt0000001"},
+        },
+        {
+            name:          "min rating",
+            ratingsPath:   writeTempFile(t, ratingsData),
+            filters:       []imdb.Filter{imdb.NewMinRatingFilter(6)},
+            expectedItems: []string{"tt0033122"},
+        },
+        {
+            name:          "min votes gzipped",
+            ratingsPath:   writeTempGzipFile(t, ratingsData),
+            filters:       []imdb.Filter{imdb.NewMinVotesFilter(1000)},
+            expectedItems: []string{"tt0000001"},
+        },
+    }
+
+    for _, test := range testTable {
+        t.Run(test.name, func(t *testing.T) {
+            imdbClient, err := imdb.New(writeTempFile(t, basicsData), 1)
+            require.NoError(t, err)
+            require.NoError(t, imdbClient.LoadRatings(test.ratingsPath))
+
+            for _, item := range requireList(t, &imdbClient, test.filters, test.expectedItems) {
+                if item.TConst == "tt0000001" {
+                    require.Equal(t, 5.7, item.AverageRating)
+                    require.Equal(t, 1845, item.NumVotes)
+                }
+            }
+        })
+    }
+}
+
+func TestLoadRatings_InvalidHeader(t *testing.T) {
+    imdbClient, err := imdb.New(writeTempFile(t, basicsData), 1)
+    require.NoError(t, err)
+
+    err = imdbClient.LoadRatings(writeTempFile(t, "tconst\taverageRating\n"))
+    require.EqualError(t, err, "invalid headers for file, expecting 3, got 2")
+}
+
+func TestNew_Gzip(t *testing.T) {
+    imdbClient, err := imdb.New(writeTempGzipFile(t, basicsData), 1)
+    require.NoError(t, err)
+
+    resp, err := imdbClient.List(context.Background())
+    require.NoError(t, err)
+    require.Len(t, resp, 3)
+}
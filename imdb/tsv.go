@@ -0,0 +1,79 @@
+package imdb
+
+import (
+    "bufio"
+    "compress/gzip"
+    "fmt"
+    "io"
+    "os"
+    "strings"
+)
+
+// gzipFile closes both the gzip reader and the underlying file.
+type gzipFile struct {
+    *gzip.Reader
+    file *os.File
+}
+
+func (g gzipFile) Close() error {
+    g.Reader.Close()
+    return g.file.Close()
+}
+
+// openFile opens a dataset file, transparently inflating it when the name ends in `.gz`.
+func openFile(path string) (io.ReadCloser, error) {
+    file, err := os.Open(path)
+    if err != nil {
+        return nil, err
+    }
+    if !strings.HasSuffix(path, ".gz") {
+        return file, nil
+    }
+
+    reader, err := gzip.NewReader(file)
+    if err != nil {
+        file.Close()
+        return nil, err
+    }
+    return gzipFile{Reader: reader, file: file}, nil
+}
+
+// newScanner returns a line scanner with a buffer large enough for the longest IMDb rows.
+func newScanner(r io.Reader) *bufio.Scanner {
+    scanner := bufio.NewScanner(r)
+    scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+    return scanner
+}
+
+// readTSV validates the header of the dataset at path and calls fn with the fields of every following row.
+func readTSV(path string, columns int, fn func(fields []string) error) error {
+    file, err := openFile(path)
+    if err != nil {
+        return err
+    }
+    defer file.Close()
+
+    scanner := newScanner(file)
+    if !scanner.Scan() {
+        if scanner.Err() != nil {
+            return scanner.Err()
+        }
+        return fmt.Errorf("empty file %s", path)
+    }
+
+    header := strings.Split(scanner.Text(), "\t")
+    if len(header) != columns {
+        return fmt.Errorf("invalid headers for file, expecting %d, got %d", columns, len(header))
+    }
+
+    for scanner.Scan() {
+        fields := strings.Split(scanner.Text(), "\t")
+        if len(fields) != columns {
+            return fmt.Errorf("invalid row for file, expecting %d columns, got %d", columns, len(fields))
+        }
+        if err := fn(fields); err != nil {
+            return err
+        }
+    }
+    return scanner.Err()
+}
@@ -2,18 +2,25 @@ package main
 
 import (
     "context"
+    "encoding/json"
+    "errors"
     "flag"
     "fmt"
+    "io/ioutil"
     "os"
     "os/signal"
+    "path/filepath"
+    "strconv"
+    "strings"
     "time"
 
+    "Azarc/contactsheet"
     "Azarc/imdb"
     "Azarc/omdb"
 )
 
-var apiKey = flag.String("apiKey", "", "the omdb API key")
-var filePath = flag.String("filePath", "title.basics.tsv", "Absolute path to the inflated `title.basics.tsv.gz` file")
+var apiKey = flag.String("apiKey", "", "the omdb API key, or a comma separated list of keys to rotate over")
+var filePath = flag.String("filePath", "title.basics.tsv", "Absolute path to the `title.basics.tsv(.gz)` file")
 var titleType = flag.String("titleType", "movie", "Filter on `titleType` column")
 var primaryTitle = flag.String("primaryTitle", "The Mask", "filter on `primaryTitle` column")
 var originalTitle = flag.String("originalTitle", "", "filter on `originalTitle` column")
@@ -22,14 +29,58 @@ var startYear = flag.Int("startYear", 0, "filter on `startYear` column")
 var endYear = flag.Int("endYear", 0, "filter on `endYear` column")
 var runtimeMinutes = flag.Int("runtimeMinutes", 0, "filter on `runtimeMinutes` column")
 var genres = flag.String("genres", "", "filter on `genres` column")
-var maxApiRequests = flag.String("maxApiRequests", "", "maximum number of requests to be made to [omdbapi](https://www.omdbapi.com/)")
+var maxApiRequests = flag.Int("maxApiRequests", 1000, "maximum number of requests to be made to [omdbapi](https://www.omdbapi.com/) per day and per key of -apiKey, tracked across runs in -quotaStateFile. 0 disables the daily quota")
 var maxRunTime = flag.Duration("maxRunTime", time.Minute, "maximum run time of the application. Format is a `time.Duration` string see [here](https://godoc.org/time#ParseDuration)")
-var maxRequests = flag.String("maxRequests", "", "maximum number of requests to send to [omdbapi](https://www.omdbapi.com/)")
+var maxRequests = flag.Int("maxRequests", 0, "maximum number of requests to send to [omdbapi](https://www.omdbapi.com/) in this run. 0 means no limit")
+var quotaStateFile = flag.String("quotaStateFile", defaultQuotaStateFile(), "file in which the daily usage of -maxApiRequests is persisted across runs, the usage is not persisted when empty")
+var requestsPerSecond = flag.Float64("requestsPerSecond", 0, "maximum number of requests per second to send to [omdbapi](https://www.omdbapi.com/). 0 means no limit")
+var plot = flag.String("plot", "", "length of the plot retrieved from [omdbapi](https://www.omdbapi.com/), `short` or full")
+var expandSeries = flag.Bool("expandSeries", false, "list the episodes of every series retrieved from [omdbapi](https://www.omdbapi.com/), one request per season")
+var maxAttempts = flag.Int("maxAttempts", omdb.DefaultRetryPolicy.MaxAttempts, "maximum number of attempts of a request to [omdbapi](https://www.omdbapi.com/) failing with a transient error")
+var omdbTimeout = flag.Duration("omdbTimeout", 30*time.Second, "maximum duration of a single request to [omdbapi](https://www.omdbapi.com/). 0 means no limit")
+var omdbFormat = flag.String("omdbFormat", "json", "response `format` requested from [omdbapi](https://www.omdbapi.com/), json or xml")
+var omdbURL = flag.String("omdbURL", "https://www.omdbapi.com", "base url of the [omdbapi](https://www.omdbapi.com/), e.g. to go through a proxy")
+var breakerFailureRatio = flag.Float64("breakerFailureRatio", 0.5, "ratio of failing requests to [omdbapi](https://www.omdbapi.com/) after which requests fail fast for -breakerCoolDown. 0 disables the circuit breaker")
+var breakerCoolDown = flag.Duration("breakerCoolDown", 30*time.Second, "how long requests to [omdbapi](https://www.omdbapi.com/) fail fast once -breakerFailureRatio is reached")
+var metadataFile = flag.String("metadataFile", "", "JSON file holding an array of items in the [omdbapi](https://www.omdbapi.com/) format, looked up before and merged with the omdbapi")
+var cacheDir = flag.String("cacheDir", "", "directory in which responses of [omdbapi](https://www.omdbapi.com/) are cached, caching is disabled when empty")
+var cacheTTL = flag.Duration("cacheTTL", 24*time.Hour, "how long a cached response is used without revalidating it")
+var cacheStaleTTL = flag.Duration("cacheStaleTTL", 24*time.Hour, "how long after -cacheTTL a cached response is still used while it is revalidated in the background")
+var cacheNegativeTTL = flag.Duration("cacheNegativeTTL", time.Hour, "how long a not found response is cached")
+var omdbWorkers = flag.Int("omdbWorkers", 4, "number of concurrent requests to [omdbapi](https://www.omdbapi.com/)")
+var posterDir = flag.String("posterDir", "", "directory into which the posters of the results are downloaded, posters are not downloaded when empty")
+var posterHeight = flag.Int("posterHeight", 0, "download posters of this height from the [omdbapi](https://www.omdbapi.com/) Poster API instead of the Poster url, requires a patron API key")
 var plotFilter = flag.String("plotFilter", "", "regex pattern to apply to the plot of a film retrieved from [omdbapi](https://www.omdbapi.com/)")
+var ratingsFilePath = flag.String("ratingsFilePath", "", "Absolute path to the `title.ratings.tsv(.gz)` file, joined onto the results when set")
+var minRating = flag.Float64("minRating", 0, "filter on a minimum `averageRating` column, requires -ratingsFilePath")
+var minVotes = flag.Int("minVotes", 0, "filter on a minimum `numVotes` column, requires -ratingsFilePath")
+var akasFilePath = flag.String("akasFilePath", "", "Absolute path to the `title.akas.tsv(.gz)` file, joined onto the results when set")
+var akaRegion = flag.String("akaRegion", "", "filter on titles with an alternative title in `region`, requires -akasFilePath")
+var akaTitle = flag.String("akaTitle", "", "filter on titles with a matching alternative `title`, requires -akasFilePath")
+var namesFilePath = flag.String("namesFilePath", "", "Absolute path to the `name.basics.tsv(.gz)` file, used to look up people by name")
+var principalsFilePath = flag.String("principalsFilePath", "", "Absolute path to the `title.principals.tsv(.gz)` file, joined onto the results when set")
+var principal = flag.String("principal", "", "filter on titles crediting the person with this `nconst`, requires -principalsFilePath")
+var person = flag.String("person", "", "filter on titles crediting the person with this `primaryName`, requires -namesFilePath and -principalsFilePath")
+var principalCategory = flag.String("principalCategory", "", "restrict -principal and -person to a `category` such as actor or director")
+var episodesFilePath = flag.String("episodesFilePath", "", "Absolute path to the `title.episode.tsv(.gz)` file, joined onto the results when set")
+var parentTConst = flag.String("parentTConst", "", "filter on episodes of the series with this `tconst`, requires -episodesFilePath")
+var seasonNumber = flag.Int("seasonNumber", 0, "filter on episodes of this `seasonNumber`, requires -episodesFilePath")
+var crewFilePath = flag.String("crewFilePath", "", "Absolute path to the `title.crew.tsv(.gz)` file, joined onto the results when set")
+var director = flag.String("director", "", "filter on titles directed by this `nconst` or primaryName, requires -crewFilePath")
+var writer = flag.String("writer", "", "filter on titles written by this `nconst` or primaryName, requires -crewFilePath")
+var networkFormat = flag.String("networkFormat", "dot", "output `format` of the network command, dot or graphml")
+var networkDepth = flag.Int("networkDepth", 1, "number of co-star hops around the person given to the network command")
+var networkMinWeight = flag.Int("networkMinWeight", 1, "minimum number of shared titles for an edge of the network command")
+var sheetColumns = flag.Int("sheetColumns", 5, "number of posters per row of the contactsheet command")
+var sheetRows = flag.Int("sheetRows", 0, "number of rows per image of the contactsheet command, 0 puts every poster on a single image")
+var sheetCellWidth = flag.Int("sheetCellWidth", 200, "width in pixels of every poster of the contactsheet command")
 var fileReadRoutines = flag.Int("fileReadRoutines", 4, "number of routines used to parse the input file")
 
 func main() {
     flag.Parse()
+    if *plot != "" && *plot != string(omdb.PlotShort) && *plot != string(omdb.PlotFull) {
+        maybeExitGracefully(fmt.Errorf("unknown plot length %q, expected short or full", *plot))
+    }
     ctx, cancel := context.WithTimeout(context.Background(), *maxRunTime)
     defer cancel()
 
@@ -51,34 +102,528 @@ func main() {
         }
     }()
 
-    filters := buildFilters()
+    if flag.Arg(0) == "title" {
+        runTitle(ctx, flag.Args()[1:])
+        return
+    }
 
     imdbClient, err := imdb.New(*filePath, *fileReadRoutines)
+    if err != nil {
+        maybeExitGracefully(err)
+    }
+    loadDatasets(&imdbClient)
+
+    switch flag.Arg(0) {
+    case "path":
+        runPath(ctx, &imdbClient, flag.Args()[1:])
+        return
+    case "network":
+        runNetwork(ctx, &imdbClient, flag.Args()[1:])
+        return
+    case "contactsheet":
+        runContactSheet(ctx, &imdbClient, flag.Args()[1:])
+        return
+    }
+
+    filters := buildFilters(&imdbClient)
+
     list, err := imdbClient.List(ctx, filters...)
     if err != nil {
         maybeExitGracefully(err)
     }
 
-    omdbClient := omdb.New(*apiKey)
+    setup := newOMDbClient()
+    omdbClient := setup.client
+    queryOptions := buildQueryOptions()
+
+    ids := make([]string, len(list))
+    for i, imdbitem := range list {
+        ids[i] = imdbitem.TConst
+    }
+
+    var omdbItems []omdb.Item
+    i := 0
+    provider := newMetadataProvider(omdbClient)
+    for result := range omdb.StreamInfo(ctx, provider, ids, *omdbWorkers, queryOptions...) {
+        imdbitem := list[i]
+        i++
+        if result.Err != nil {
+            skipOrStop(result.ID, result.Err)
+            continue
+        }
+        omdbItem := result.Item
+        omdbItems = append(omdbItems, omdbItem)
+        fmt.Printf("imdbItem: %#v\nomdbItem: %#v\n\n", imdbitem, omdbItem)
+        if *expandSeries && omdbItem.Type == "series" {
+            printSeasons(ctx, omdbClient, omdbItem)
+        }
+    }
+    if ctx.Err() != nil {
+        // the stream closes early once the context is done
+        maybeExitGracefully(ctx.Err())
+    }
+    if *posterDir != "" {
+        downloadPosters(ctx, omdbClient, omdbItems)
+    }
+    setup.printCacheStats()
+    setup.printBreakerStats()
+    setup.printKeyUsage()
+    setup.close()
+}
+
+// downloadPosters stores the posters of items in -posterDir.
+func downloadPosters(ctx context.Context, omdbClient omdb.Client, items []omdb.Item) {
+    store, err := omdb.NewPosterStore(omdbClient, *posterDir, omdb.PosterConfig{
+        Concurrency: *omdbWorkers,
+        Height:      *posterHeight,
+    })
+    if err != nil {
+        maybeExitGracefully(err)
+    }
+    for _, result := range store.DownloadAll(ctx, items) {
+        if result.Err != nil {
+            skipOrStop("poster of "+result.ImdbID, result.Err)
+            continue
+        }
+        fmt.Printf("poster of %s: %s\n", result.ImdbID, result.Path)
+    }
+}
+
+// printSeasons lists the episodes of every season of a series.
+func printSeasons(ctx context.Context, omdbClient omdb.Client, series omdb.Item) {
+    totalSeasons, err := strconv.Atoi(series.TotalSeasons)
+    if err != nil {
+        fmt.Printf("skipping seasons of %s: %v\n\n", series.ImdbID, err)
+        return
+    }
+    for i := 1; i <= totalSeasons; i++ {
+        season, err := omdbClient.Season(ctx, series.ImdbID, i)
+        if err != nil {
+            skipOrStop(fmt.Sprintf("season %d of %s", i, series.ImdbID), err)
+            continue
+        }
+        typed, err := season.Typed()
+        if err != nil {
+            fmt.Printf("skipping season %d of %s: %v\n", i, series.ImdbID, err)
+            continue
+        }
+        for _, episode := range typed.Episodes {
+            released := "N/A"
+            if !episode.Released.IsZero() {
+                released = episode.Released.Format("2006-01-02")
+            }
+            fmt.Printf("    S%02dE%02d %s %s released %s rated %.1f\n", typed.Season, episode.Episode, episode.ImdbID, episode.Title, released, episode.ImdbRating)
+        }
+    }
+    fmt.Printf("\n")
+}
+
+// omdbSetup is the omdb client configured from the command line, together with
+// the parts of it that are reported on after the run.
+type omdbSetup struct {
+    client omdb.Client
+    keys   *omdb.KeyPool
+    // breaker is nil when -breakerFailureRatio is 0.
+    breaker *omdb.CircuitBreaker
+    // cache is nil unless -cacheDir is given.
+    cache *omdb.DiskCache
+}
 
-    for _, imdbitem := range list {
-        omdbItem, err := omdbClient.Info(ctx, imdbitem.TConst)
+// newOMDbClient configures the omdb client from the command line.
+func newOMDbClient() omdbSetup {
+    var setup omdbSetup
+    retry := omdb.DefaultRetryPolicy
+    retry.MaxAttempts = *maxAttempts
+    if *omdbFormat != string(omdb.FormatJSON) && *omdbFormat != string(omdb.FormatXML) {
+        maybeExitGracefully(fmt.Errorf("unknown omdb format %q", *omdbFormat))
+    }
+    keys := splitKeys(*apiKey)
+    if len(keys) == 0 {
+        maybeExitGracefully(errors.New("usage: -apiKey <key>[,<key>...] is required to query the omdbapi"))
+    }
+    setup.keys = omdb.NewKeyPool(keys...)
+    opts := []omdb.Option{
+        omdb.WithRetry(retry),
+        omdb.WithKeyPool(setup.keys),
+        omdb.WithBaseURL(*omdbURL),
+        omdb.WithTimeout(*omdbTimeout),
+        omdb.WithUserAgent("Azarc/imdb"),
+        omdb.WithFormat(omdb.Format(*omdbFormat)),
+    }
+    if *requestsPerSecond > 0 {
+        opts = append(opts, omdb.WithRateLimiter(omdb.NewRateLimiter(*requestsPerSecond, 1)))
+    }
+    if *maxApiRequests > 0 {
+        // every key has a daily limit of its own, retiring exhausted keys is left to the key pool
+        quota, err := omdb.NewQuota(*maxApiRequests*len(keys), *quotaStateFile)
         if err != nil {
             maybeExitGracefully(err)
         }
-        fmt.Printf("imdbItem: %#v\nomdbItem: %#v\n\n", imdbitem, omdbItem)
+        opts = append(opts, omdb.WithQuota(quota))
+    }
+    if *maxRequests > 0 {
+        quota, err := omdb.NewQuota(*maxRequests, "")
+        if err != nil {
+            maybeExitGracefully(err)
+        }
+        opts = append(opts, omdb.WithQuota(quota))
+    }
+    if *breakerFailureRatio > 0 {
+        setup.breaker = omdb.NewCircuitBreaker(omdb.BreakerConfig{
+            FailureRatio: *breakerFailureRatio,
+            CoolDown:     *breakerCoolDown,
+            OnStateChange: func(from, to omdb.BreakerState) {
+                fmt.Printf("omdb circuit breaker: %s -> %s\n", from, to)
+            },
+        })
+        opts = append(opts, omdb.WithCircuitBreaker(setup.breaker))
+    }
+    if *cacheDir != "" {
+        var err error
+        setup.cache, err = omdb.NewDiskCache(*cacheDir, omdb.CacheConfig{
+            TTL:                  *cacheTTL,
+            StaleWhileRevalidate: *cacheStaleTTL,
+            NegativeTTL:          *cacheNegativeTTL,
+        })
+        if err != nil {
+            maybeExitGracefully(err)
+        }
+        opts = append(opts, omdb.WithCache(setup.cache))
+    }
+    setup.client = omdb.New("", opts...)
+    return setup
+}
+
+// splitKeys splits the comma separated keys of -apiKey, ignoring whitespace and empty entries.
+func splitKeys(value string) []string {
+    var keys []string
+    for _, key := range strings.Split(value, ",") {
+        key = strings.TrimSpace(key)
+        if key != "" {
+            keys = append(keys, key)
+        }
+    }
+    return keys
+}
+
+// defaultQuotaStateFile returns the default of -quotaStateFile in the cache directory of the user.
+func defaultQuotaStateFile() string {
+    dir, err := os.UserCacheDir()
+    if err != nil {
+        return ".omdb-quota.json"
+    }
+    return filepath.Join(dir, "azarc", "omdb-quota.json")
+}
+
+// newMetadataProvider looks titles up with omdbClient, preceded by the items of -metadataFile when set.
+func newMetadataProvider(omdbClient omdb.Client) omdb.MetadataProvider {
+    if *metadataFile == "" {
+        return omdbClient
+    }
+    data, err := ioutil.ReadFile(*metadataFile)
+    if err != nil {
+        maybeExitGracefully(err)
+    }
+    var items []omdb.Item
+    err = json.Unmarshal(data, &items)
+    if err != nil {
+        maybeExitGracefully(fmt.Errorf("invalid metadataFile %s: %w", *metadataFile, err))
+    }
+    return omdb.NewCompositeProvider(omdb.NewStaticProvider(items...), omdbClient)
+}
+
+// close stops the background refreshes of the cache, if it is enabled.
+func (s omdbSetup) close() {
+    if s.cache != nil {
+        s.cache.Close()
+    }
+}
+
+// printKeyUsage reports the requests sent with every API key.
+func (s omdbSetup) printKeyUsage() {
+    for _, usage := range s.keys.Usage() {
+        fmt.Printf("apiKey %s: %d requests", maskKey(usage.Key), usage.Requests)
+        if !usage.RetiredUntil.IsZero() {
+            fmt.Printf(", retired until %s: %v", usage.RetiredUntil.Format(time.RFC3339), usage.RetiredBy)
+        }
+        fmt.Printf("\n")
+    }
+}
+
+// maskKey hides all but the last 4 characters of an API key, so it can be printed.
+func maskKey(key string) string {
+    if len(key) <= 4 {
+        return strings.Repeat("*", len(key))
+    }
+    return strings.Repeat("*", len(key)-4) + key[len(key)-4:]
+}
+
+// printBreakerStats reports how often the circuit breaker opened, if it is enabled.
+func (s omdbSetup) printBreakerStats() {
+    if s.breaker == nil {
+        return
+    }
+    stats := s.breaker.Stats()
+    fmt.Printf("circuit breaker: %s, opened %d times, %d requests rejected\n", stats.State, stats.Opened, stats.Rejected)
+}
+
+// printCacheStats reports how the omdb requests were served, if the cache is enabled.
+func (s omdbSetup) printCacheStats() {
+    if s.cache == nil {
+        return
+    }
+    stats := s.cache.Stats()
+    fmt.Printf("cache: %d hits, %d stale hits, %d negative hits, %d misses, %d revalidations\n",
+        stats.Hits, stats.StaleHits, stats.NegativeHits, stats.Misses, stats.Revalidations)
+}
+
+// buildQueryOptions returns the options applied to every omdb lookup.
+func buildQueryOptions() []omdb.QueryOption {
+    var queryOptions []omdb.QueryOption
+    if *plot != "" {
+        queryOptions = append(queryOptions, omdb.WithPlot(omdb.Plot(*plot)))
+    }
+    return queryOptions
+}
+
+// omdbTypes maps the IMDb `titleType` values onto the omdb `type` parameter.
+var omdbTypes = map[string]string{
+    "movie":     "movie",
+    "tvMovie":   "movie",
+    "tvSeries":  "series",
+    "tvEpisode": "episode",
+}
+
+// runTitle looks a title up on the omdbapi by name, without scanning title.basics.
+// -startYear and an explicitly set -titleType narrow the lookup.
+func runTitle(ctx context.Context, args []string) {
+    if len(args) != 1 {
+        maybeExitGracefully(errors.New("usage: title <title>"))
+    }
+
+    queryOptions := buildQueryOptions()
+    if *startYear != 0 {
+        queryOptions = append(queryOptions, omdb.WithYear(*startYear))
+    }
+    if isFlagSet("titleType") {
+        omdbType, ok := omdbTypes[*titleType]
+        if !ok {
+            maybeExitGracefully(fmt.Errorf("titleType %q has no omdb equivalent", *titleType))
+        }
+        queryOptions = append(queryOptions, omdb.WithType(omdbType))
+    }
+
+    omdbItem, err := newMetadataProvider(newOMDbClient().client).Title(ctx, args[0], queryOptions...)
+    if err != nil {
+        skipOrStop(args[0], err)
+        return
+    }
+    fmt.Printf("omdbItem: %#v\n\n", omdbItem)
+}
+
+// runPath prints the shortest chain of shared titles between two people, each given as an nconst or primaryName.
+// The graph only contains the titles matching the command line filters, e.g. `-titleType movie`.
+func runPath(ctx context.Context, imdbClient *imdb.Client, args []string) {
+    if len(args) != 2 {
+        maybeExitGracefully(errors.New("usage: path <nconst|primaryName> <nconst|primaryName>"))
+    }
+    if !isFlagSet("primaryTitle") {
+        *primaryTitle = ""
+    }
+
+    list, err := imdbClient.List(ctx, buildFilters(imdbClient)...)
+    if err != nil {
+        maybeExitGracefully(err)
+    }
+    graph := imdb.NewGraph(list)
+
+    var shortest *imdb.Path
+    for _, from := range resolvePeople(imdbClient, args[0]) {
+        for _, to := range resolvePeople(imdbClient, args[1]) {
+            path, err := graph.ShortestPath(from, to)
+            if err == imdb.ErrNoPath {
+                continue
+            }
+            if shortest == nil || len(path.People) < len(shortest.People) {
+                shortest = &path
+            }
+        }
+    }
+    if shortest == nil {
+        fmt.Printf("no path between %q and %q\n", args[0], args[1])
+        return
+    }
+
+    fmt.Printf("degrees of separation: %d\n", len(shortest.Titles))
+    for i, nconst := range shortest.People {
+        fmt.Printf("%s\n", personName(imdbClient, nconst))
+        if i < len(shortest.Titles) {
+            title, _ := graph.Title(shortest.Titles[i])
+            fmt.Printf("    %s (%d) %s\n", title.PrimaryTitle, title.StartYear, title.TConst)
+        }
+    }
+}
+
+// runNetwork writes the co-star network of the titles matching the command line filters to stdout,
+// restricted to the people around a person when one is given as an nconst or primaryName.
+func runNetwork(ctx context.Context, imdbClient *imdb.Client, args []string) {
+    if len(args) > 1 {
+        maybeExitGracefully(errors.New("usage: network [nconst|primaryName]"))
+    }
+    if !isFlagSet("primaryTitle") {
+        *primaryTitle = ""
+    }
+
+    list, err := imdbClient.List(ctx, buildFilters(imdbClient)...)
+    if err != nil {
+        maybeExitGracefully(err)
+    }
+    graph := imdb.NewGraph(list)
+
+    network := graph.Network(*networkMinWeight)
+    if len(args) == 1 {
+        network = imdb.Network{}
+        for _, nconst := range resolvePeople(imdbClient, args[0]) {
+            around := graph.CoStars(nconst, *networkDepth, *networkMinWeight)
+            if len(around.People) > len(network.People) {
+                network = around
+            }
+        }
+    }
+
+    label := func(nconst string) string {
+        p, ok := imdbClient.Person(nconst)
+        if !ok {
+            return nconst
+        }
+        return p.PrimaryName
+    }
+    switch *networkFormat {
+    case "dot":
+        err = network.WriteDOT(os.Stdout, label)
+    case "graphml":
+        err = network.WriteGraphML(os.Stdout, label)
+    default:
+        err = fmt.Errorf("unknown network format %q", *networkFormat)
+    }
+    if err != nil {
+        maybeExitGracefully(err)
+    }
+}
+
+// runContactSheet renders the posters of the titles matching the command line filters into a grid, using
+// the posters previously downloaded into -posterDir. The image format follows the extension of the output file.
+func runContactSheet(ctx context.Context, imdbClient *imdb.Client, args []string) {
+    if len(args) != 1 || *posterDir == "" {
+        maybeExitGracefully(errors.New("usage: -posterDir <dir> contactsheet <output.png|output.jpg>"))
+    }
+
+    list, err := imdbClient.List(ctx, buildFilters(imdbClient)...)
+    if err != nil {
+        maybeExitGracefully(err)
+    }
+    if len(list) == 0 {
+        fmt.Printf("no titles matched\n")
+        return
+    }
+    // Lookup only reads -posterDir, so the store needs no API keys, quota or cache
+    store, err := omdb.NewPosterStore(omdb.New(""), *posterDir, omdb.PosterConfig{Height: *posterHeight})
+    if err != nil {
+        maybeExitGracefully(err)
+    }
+
+    entries := make([]contactsheet.Entry, len(list))
+    for i, item := range list {
+        entries[i] = contactsheet.Entry{Title: item.PrimaryTitle}
+        if item.StartYear != 0 {
+            entries[i].Year = strconv.Itoa(item.StartYear)
+        }
+        if path, ok := store.Lookup(item.TConst); ok {
+            entries[i].PosterPath = path
+        }
+    }
+
+    paths, err := contactsheet.WriteFiles(args[0], entries, contactsheet.Config{
+        Columns:      *sheetColumns,
+        Rows:         *sheetRows,
+        CellWidth:    *sheetCellWidth,
+        PosterHeight: *sheetCellWidth * 3 / 2,
+    })
+    if err != nil {
+        maybeExitGracefully(err)
+    }
+    for _, path := range paths {
+        fmt.Printf("contact sheet: %s\n", path)
+    }
+}
+
+// personName formats a person for output, falling back to the nconst when names are not loaded.
+func personName(imdbClient *imdb.Client, nconst string) string {
+    p, ok := imdbClient.Person(nconst)
+    if !ok {
+        return nconst
+    }
+    return fmt.Sprintf("%s %s", p.PrimaryName, nconst)
+}
+
+// isFlagSet reports whether the flag with the given name was passed on the command line.
+func isFlagSet(name string) bool {
+    set := false
+    flag.Visit(func(f *flag.Flag) {
+        if f.Name == name {
+            set = true
+        }
+    })
+    return set
+}
+
+// skipOrStop decides whether an omdb error only affects the item with the given id, or stops the run.
+func skipOrStop(id string, err error) {
+    switch {
+    case errors.Is(err, context.DeadlineExceeded), errors.Is(err, context.Canceled):
+        maybeExitGracefully(err)
+    case errors.Is(err, omdb.ErrInvalidAPIKey), errors.Is(err, omdb.ErrRequestLimitReached), errors.Is(err, omdb.ErrQuotaExhausted), errors.Is(err, omdb.ErrNoAPIKeys):
+        fmt.Printf("Stopping execution: %v\n", err)
+        os.Exit(1)
+    default:
+        // not found, a transient failure that outlasted the retries, or the circuit breaker failing fast
+        fmt.Printf("skipping %s: %v\n\n", id, err)
     }
 }
 
 func maybeExitGracefully(err error){
-    if err == context.DeadlineExceeded || err == context.Canceled {
+    if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
         fmt.Printf("Stopping execution\n")
         os.Exit(0)
     }
     panic(err)
 }
 
-func buildFilters() []imdb.Filter{
+// loadDatasets loads every optional IMDb dataset whose path was given on the command line.
+func loadDatasets(imdbClient *imdb.Client) {
+    datasets := []struct {
+        path string
+        load func(string) error
+    }{
+        {*ratingsFilePath, imdbClient.LoadRatings},
+        {*akasFilePath, imdbClient.LoadAkas},
+        {*namesFilePath, imdbClient.LoadNames},
+        {*principalsFilePath, imdbClient.LoadPrincipals},
+        {*episodesFilePath, imdbClient.LoadEpisodes},
+        {*crewFilePath, imdbClient.LoadCrew},
+    }
+    for _, dataset := range datasets {
+        if dataset.path == "" {
+            continue
+        }
+        err := dataset.load(dataset.path)
+        if err != nil {
+            maybeExitGracefully(err)
+        }
+    }
+}
+
+func buildFilters(imdbClient *imdb.Client) []imdb.Filter{
     var filters []imdb.Filter
     if *titleType != ""{
         filters = append(filters,imdb.NewTitleTypeFilter(*titleType))
@@ -101,5 +646,51 @@ func buildFilters() []imdb.Filter{
     if *runtimeMinutes != 0 {
         filters = append(filters, imdb.NewRuntimeMinutesFilter(*runtimeMinutes))
     }
+    if *minRating != 0 {
+        filters = append(filters, imdb.NewMinRatingFilter(*minRating))
+    }
+    if *minVotes != 0 {
+        filters = append(filters, imdb.NewMinVotesFilter(*minVotes))
+    }
+    if *akaRegion != "" {
+        filters = append(filters, imdb.NewAkaRegionFilter(*akaRegion))
+    }
+    if *akaTitle != "" {
+        filters = append(filters, imdb.NewAkaTitleFilter(*akaTitle))
+    }
+    if *principal != "" {
+        filters = append(filters, imdb.NewPrincipalFilter(*principalCategory, *principal))
+    }
+    if *person != "" {
+        filters = append(filters, imdb.NewPrincipalFilter(*principalCategory, resolvePeople(imdbClient, *person)...))
+    }
+    if *parentTConst != "" {
+        filters = append(filters, imdb.NewParentTConstFilter(*parentTConst))
+    }
+    if *seasonNumber != 0 {
+        filters = append(filters, imdb.NewSeasonNumberFilter(*seasonNumber))
+    }
+    if *director != "" {
+        filters = append(filters, imdb.NewDirectorFilter(resolvePeople(imdbClient, *director)...))
+    }
+    if *writer != "" {
+        filters = append(filters, imdb.NewWriterFilter(resolvePeople(imdbClient, *writer)...))
+    }
     return filters
 }
+
+// resolvePeople returns the nconsts for a command line value that is either an nconst or a primaryName.
+func resolvePeople(imdbClient *imdb.Client, value string) []string {
+    if _, err := fmt.Sscanf(value, "nm%d", new(int)); err == nil {
+        return []string{value}
+    }
+
+    var nconsts []string
+    for _, p := range imdbClient.PeopleByName(value) {
+        nconsts = append(nconsts, p.NConst)
+    }
+    if len(nconsts) == 0 {
+        maybeExitGracefully(fmt.Errorf("no person named %q, is -namesFilePath set?", value))
+    }
+    return nconsts
+}
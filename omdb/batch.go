@@ -0,0 +1,97 @@
+package omdb
+
+import (
+    "context"
+    "sync"
+)
+
+// Result is the outcome of looking up a single id of a batch.
+type Result struct {
+    ID   string
+    Item Item
+    Err  error
+}
+
+// InfoStream looks up ids with up to workers concurrent requests and sends the
+// results in the order of ids, closing the channel after the last one. A
+// failed lookup is reported in its Result and does not stop the others. The
+// workers share the rate limiter, quotas and cache of the client, and
+// duplicate ids in flight at the same time are requested once.
+//
+// Callers must either receive every result or cancel ctx. Once ctx is done the
+// channel is closed, possibly before a result was sent for every id.
+func (c Client) InfoStream(ctx context.Context, ids []string, workers int, opts ...QueryOption) <-chan Result {
+    return StreamInfo(ctx, c, ids, workers, opts...)
+}
+
+// StreamInfo looks up ids from provider with up to workers concurrent lookups,
+// see Client.InfoStream.
+func StreamInfo(ctx context.Context, provider MetadataProvider, ids []string, workers int, opts ...QueryOption) <-chan Result {
+    if workers < 1 {
+        workers = 1
+    }
+
+    results := make([]Result, len(ids))
+    done := make([]chan struct{}, len(ids))
+    for i := range done {
+        done[i] = make(chan struct{})
+    }
+
+    jobs := make(chan int)
+    go func() {
+        defer close(jobs)
+        for i := range ids {
+            select {
+            case jobs <- i:
+            case <-ctx.Done():
+                return
+            }
+        }
+    }()
+
+    wg := new(sync.WaitGroup)
+    wg.Add(workers)
+    for w := 0; w < workers; w++ {
+        go func() {
+            defer wg.Done()
+            for i := range jobs {
+                item, err := provider.Info(ctx, ids[i], opts...)
+                results[i] = Result{ID: ids[i], Item: item, Err: err}
+                close(done[i])
+            }
+        }()
+    }
+
+    out := make(chan Result)
+    go func() {
+        defer close(out)
+        defer wg.Wait()
+        for i := range ids {
+            select {
+            case <-done[i]:
+            case <-ctx.Done():
+                return
+            }
+            select {
+            case out <- results[i]:
+            case <-ctx.Done():
+                return
+            }
+        }
+    }()
+    return out
+}
+
+// InfoBatch looks up ids with up to workers concurrent requests, see InfoStream.
+// The results are in the order of ids, ids not looked up before ctx was done
+// failing with the error of ctx.
+func (c Client) InfoBatch(ctx context.Context, ids []string, workers int, opts ...QueryOption) []Result {
+    results := make([]Result, 0, len(ids))
+    for result := range c.InfoStream(ctx, ids, workers, opts...) {
+        results = append(results, result)
+    }
+    for _, id := range ids[len(results):] {
+        results = append(results, Result{ID: id, Err: ctx.Err()})
+    }
+    return results
+}
@@ -0,0 +1,129 @@
+package omdb_test
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "math/rand"
+    "net/http"
+    "net/http/httptest"
+    "sync/atomic"
+    "testing"
+    "time"
+
+    "github.com/stretchr/testify/require"
+
+    "Azarc/omdb"
+)
+
+func TestInfoBatch(t *testing.T) {
+    var inFlight, maxInFlight int32
+    testServer := httptest.NewServer(
+        http.HandlerFunc(
+            func(writer http.ResponseWriter, req *http.Request) {
+                n := atomic.AddInt32(&inFlight, 1)
+                defer atomic.AddInt32(&inFlight, -1)
+                for {
+                    max := atomic.LoadInt32(&maxInFlight)
+                    if n <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, n) {
+                        break
+                    }
+                }
+                time.Sleep(time.Duration(rand.Intn(5)) * time.Millisecond)
+
+                id := req.URL.Query().Get("i")
+                if id == "tt0000013" {
+                    _, _ = writer.Write([]byte(`{"Response":"False","Error":"Incorrect IMDb ID."}`))
+                    return
+                }
+                _, _ = fmt.Fprintf(writer, `{"Title":"Title %s","imdbID":"%s","Response":"True"}`, id, id)
+            },
+        ),
+    )
+    defer testServer.Close()
+
+    var ids []string
+    for i := 0; i < 40; i++ {
+        ids = append(ids, fmt.Sprintf("tt%07d", i))
+    }
+
+    client := omdb.NewWithURL(testServer.URL, "apiKey")
+    results := client.InfoBatch(context.Background(), ids, 4)
+
+    require.Len(t, results, len(ids))
+    for i, result := range results {
+        require.Equal(t, ids[i], result.ID)
+        if result.ID == "tt0000013" {
+            require.True(t, errors.Is(result.Err, omdb.ErrNotFound))
+            continue
+        }
+        require.NoError(t, result.Err)
+        require.Equal(t, ids[i], result.Item.ImdbID)
+    }
+    require.True(t, atomic.LoadInt32(&maxInFlight) <= 4)
+    require.True(t, atomic.LoadInt32(&maxInFlight) > 1)
+}
+
+func TestInfoBatch_ContextCancelled(t *testing.T) {
+    testServer := httptest.NewServer(
+        http.HandlerFunc(
+            func(writer http.ResponseWriter, req *http.Request) {
+                t.Error("no request expected")
+            },
+        ),
+    )
+    defer testServer.Close()
+
+    ctx, cancel := context.WithCancel(context.Background())
+    cancel()
+
+    client := omdb.NewWithURL(testServer.URL, "apiKey")
+    results := client.InfoBatch(ctx, []string{"tt0000001", "tt0000002"}, 2)
+
+    require.Len(t, results, 2)
+    for _, result := range results {
+        require.True(t, errors.Is(result.Err, context.Canceled))
+    }
+}
+
+func TestInfoStream_ConsumerStops(t *testing.T) {
+    testServer := httptest.NewServer(
+        http.HandlerFunc(
+            func(writer http.ResponseWriter, req *http.Request) {
+                id := req.URL.Query().Get("i")
+                _, _ = fmt.Fprintf(writer, `{"Title":"Title %s","imdbID":"%s","Response":"True"}`, id, id)
+            },
+        ),
+    )
+    defer testServer.Close()
+
+    var ids []string
+    for i := 0; i < 40; i++ {
+        ids = append(ids, fmt.Sprintf("tt%07d", i))
+    }
+
+    ctx, cancel := context.WithCancel(context.Background())
+    defer cancel()
+    client := omdb.NewWithURL(testServer.URL, "apiKey")
+    stream := client.InfoStream(ctx, ids, 4)
+
+    result := <-stream
+    require.NoError(t, result.Err)
+    require.Equal(t, ids[0], result.ID)
+
+    // the consumer stops receiving, cancelling releases the goroutines of the stream
+    time.Sleep(20 * time.Millisecond)
+    cancel()
+
+    closed := make(chan struct{})
+    go func() {
+        for range stream {
+        }
+        close(closed)
+    }()
+    select {
+    case <-closed:
+    case <-time.After(time.Second):
+        require.FailNow(t, "stream not closed after cancelling")
+    }
+}
@@ -0,0 +1,286 @@
+package omdb
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "sync"
+    "time"
+)
+
+// ErrCircuitOpen is matched by the CircuitOpenError returned while a CircuitBreaker rejects requests.
+var ErrCircuitOpen = errors.New("circuit breaker open")
+
+// CircuitOpenError is returned without sending the request while the circuit is open.
+type CircuitOpenError struct {
+    // RetryAt is when the circuit lets a trial request through again.
+    RetryAt time.Time
+}
+
+func (e *CircuitOpenError) Error() string {
+    return fmt.Sprintf("%v until %s", ErrCircuitOpen, e.RetryAt.Format(time.RFC3339))
+}
+
+// Is makes errors.Is(err, ErrCircuitOpen) match.
+func (e *CircuitOpenError) Is(target error) bool {
+    return target == ErrCircuitOpen
+}
+
+// BreakerState is the state of a CircuitBreaker.
+type BreakerState int
+
+const (
+    // StateClosed lets every request through, counting failures.
+    StateClosed BreakerState = iota
+    // StateOpen fails every request until the cool-down is over.
+    StateOpen
+    // StateHalfOpen lets a few trial requests through to decide whether to close again.
+    StateHalfOpen
+)
+
+func (s BreakerState) String() string {
+    switch s {
+    case StateClosed:
+        return "closed"
+    case StateOpen:
+        return "open"
+    case StateHalfOpen:
+        return "half-open"
+    }
+    return fmt.Sprintf("BreakerState(%d)", int(s))
+}
+
+// BreakerConfig configures a CircuitBreaker.
+type BreakerConfig struct {
+    // FailureRatio of failed requests opening the circuit, defaulting to 0.5.
+    FailureRatio float64
+    // MinRequests is the number of requests needed before FailureRatio applies, defaulting to 10.
+    MinRequests int
+    // Window resets the counts of a closed circuit periodically, 0 keeping them until the circuit opens.
+    Window time.Duration
+    // CoolDown is how long the circuit stays open before trial requests are sent, defaulting to 30s.
+    CoolDown time.Duration
+    // HalfOpenRequests is the number of trial requests that all have to succeed
+    // to close the circuit again, defaulting to 1.
+    HalfOpenRequests int
+    // OnStateChange, when set, is called after every state change, e.g. for logging.
+    OnStateChange func(from, to BreakerState)
+}
+
+// BreakerStats is a snapshot of a CircuitBreaker.
+type BreakerStats struct {
+    State BreakerState
+    // Requests and Failures are counted since the last state change or Window.
+    Requests int64
+    Failures int64
+    // Opened counts how often the circuit opened.
+    Opened int64
+    // Rejected counts the requests failed with a CircuitOpenError.
+    Rejected int64
+}
+
+// CircuitBreaker stops sending requests while the omdbapi is failing. Only
+// server errors, requests to slow down and network errors count as failures, a
+// title not being found is a successful request. A single CircuitBreaker can be
+// shared between clients.
+type CircuitBreaker struct {
+    config BreakerConfig
+    now    func() time.Time
+
+    mu         sync.Mutex
+    stats      BreakerStats
+    generation int64
+    expiry     time.Time
+    probes     int
+}
+
+// NewCircuitBreaker returns a closed CircuitBreaker.
+func NewCircuitBreaker(config BreakerConfig) *CircuitBreaker {
+    if config.FailureRatio <= 0 {
+        config.FailureRatio = 0.5
+    }
+    if config.MinRequests < 1 {
+        config.MinRequests = 10
+    }
+    if config.CoolDown <= 0 {
+        config.CoolDown = 30 * time.Second
+    }
+    if config.HalfOpenRequests < 1 {
+        config.HalfOpenRequests = 1
+    }
+    b := &CircuitBreaker{
+        config: config,
+        now:    time.Now,
+    }
+    b.expiry = b.windowEnd(b.now())
+    return b
+}
+
+// WithCircuitBreaker fails requests fast with a CircuitOpenError while b is open.
+func WithCircuitBreaker(b *CircuitBreaker) Option {
+    return func(c *Client) {
+        c.breaker = b
+    }
+}
+
+// State returns the current state, moving an open circuit whose cool-down is over to half-open.
+func (b *CircuitBreaker) State() BreakerState {
+    return b.Stats().State
+}
+
+// Stats returns a snapshot of the state and counts.
+func (b *CircuitBreaker) Stats() BreakerStats {
+    b.mu.Lock()
+    changed := b.advance(b.now())
+    stats := b.stats
+    b.mu.Unlock()
+    b.notify(changed)
+    return stats
+}
+
+// allow reserves a request, returning the generation to pass to done.
+func (b *CircuitBreaker) allow() (int64, error) {
+    b.mu.Lock()
+    now := b.now()
+    changed := b.advance(now)
+    generation := b.generation
+
+    var err error
+    switch {
+    case b.stats.State == StateOpen:
+        err = &CircuitOpenError{RetryAt: b.expiry}
+    case b.stats.State == StateHalfOpen && b.probes >= b.config.HalfOpenRequests:
+        err = &CircuitOpenError{RetryAt: now}
+    case b.stats.State == StateHalfOpen:
+        b.probes++
+    }
+    if err != nil {
+        b.stats.Rejected++
+    }
+    b.mu.Unlock()
+    b.notify(changed)
+    return generation, err
+}
+
+// breakerOutcome is how a request reserved by allow affects a CircuitBreaker.
+type breakerOutcome int
+
+const (
+    // outcomeIgnored is a request that was not sent or whose context ended, saying nothing about the omdbapi.
+    outcomeIgnored breakerOutcome = iota
+    outcomeSuccess
+    outcomeFailure
+)
+
+// done records the outcome of a request reserved by allow.
+func (b *CircuitBreaker) done(generation int64, outcome breakerOutcome) {
+    b.mu.Lock()
+    now := b.now()
+    changed := b.advance(now)
+    if generation != b.generation {
+        // the state changed while the request was sent
+        b.mu.Unlock()
+        b.notify(changed)
+        return
+    }
+
+    switch {
+    case outcome == outcomeIgnored:
+        if b.stats.State == StateHalfOpen {
+            b.probes--
+        }
+    case b.stats.State == StateClosed:
+        b.stats.Requests++
+        if outcome == outcomeFailure {
+            b.stats.Failures++
+        }
+        if b.stats.Requests >= int64(b.config.MinRequests) &&
+            float64(b.stats.Failures) >= b.config.FailureRatio*float64(b.stats.Requests) {
+            changed = append(changed, b.setState(StateOpen, now)...)
+        }
+    case b.stats.State == StateHalfOpen:
+        b.stats.Requests++
+        if outcome == outcomeFailure {
+            b.stats.Failures++
+            changed = append(changed, b.setState(StateOpen, now)...)
+        } else if b.stats.Requests >= int64(b.config.HalfOpenRequests) {
+            changed = append(changed, b.setState(StateClosed, now)...)
+        }
+    }
+    b.mu.Unlock()
+    b.notify(changed)
+}
+
+// advance applies the transitions due by now, returning the state changes for notify.
+func (b *CircuitBreaker) advance(now time.Time) []BreakerState {
+    switch b.stats.State {
+    case StateOpen:
+        if !now.Before(b.expiry) {
+            return b.setState(StateHalfOpen, now)
+        }
+    case StateClosed:
+        if !b.expiry.IsZero() && !now.Before(b.expiry) {
+            b.generation++
+            b.stats.Requests = 0
+            b.stats.Failures = 0
+            b.expiry = b.windowEnd(now)
+        }
+    }
+    return nil
+}
+
+// setState moves to state, resetting the counts. It returns the old and new state for notify.
+func (b *CircuitBreaker) setState(state BreakerState, now time.Time) []BreakerState {
+    from := b.stats.State
+    b.generation++
+    b.stats.State = state
+    b.stats.Requests = 0
+    b.stats.Failures = 0
+    b.probes = 0
+    switch state {
+    case StateOpen:
+        b.stats.Opened++
+        b.expiry = now.Add(b.config.CoolDown)
+    case StateClosed:
+        b.expiry = b.windowEnd(now)
+    default:
+        b.expiry = time.Time{}
+    }
+    return []BreakerState{from, state}
+}
+
+func (b *CircuitBreaker) windowEnd(now time.Time) time.Time {
+    if b.config.Window <= 0 {
+        return time.Time{}
+    }
+    return now.Add(b.config.Window)
+}
+
+// notify calls OnStateChange, outside of the lock, for pairs of old and new states.
+func (b *CircuitBreaker) notify(changed []BreakerState) {
+    if b.config.OnStateChange == nil {
+        return
+    }
+    for i := 0; i+1 < len(changed); i += 2 {
+        b.config.OnStateChange(changed[i], changed[i+1])
+    }
+}
+
+// outcome classifies the result of a request. Only server errors, requests to
+// slow down and network errors mean the omdbapi is unhealthy.
+func outcome(ctx context.Context, err error) breakerOutcome {
+    switch {
+    case ctx.Err() != nil, errors.Is(err, ErrQuotaExhausted), errors.Is(err, ErrNoAPIKeys):
+        // the request was not sent or its result is unknown
+        return outcomeIgnored
+    case err == nil:
+        return outcomeSuccess
+    case errors.Is(err, ErrServer), errors.Is(err, ErrTooManyRequests):
+        return outcomeFailure
+    }
+    var transportErr transportError
+    if errors.As(err, &transportErr) {
+        return outcomeFailure
+    }
+    return outcomeSuccess
+}
@@ -0,0 +1,141 @@
+package omdb
+
+import (
+    "context"
+    "errors"
+    "net/http"
+    "net/http/httptest"
+    "sync/atomic"
+    "testing"
+    "time"
+
+    "github.com/stretchr/testify/require"
+)
+
+func TestCircuitBreaker_States(t *testing.T) {
+    clock := &fakeClock{now: time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)}
+    var changes []string
+    b := NewCircuitBreaker(BreakerConfig{
+        FailureRatio:     0.5,
+        MinRequests:      4,
+        CoolDown:         time.Minute,
+        HalfOpenRequests: 2,
+        OnStateChange: func(from, to BreakerState) {
+            changes = append(changes, from.String()+"->"+to.String())
+        },
+    })
+    b.now = clock.Now
+
+    request := func(result breakerOutcome) error {
+        generation, err := b.allow()
+        if err != nil {
+            return err
+        }
+        b.done(generation, result)
+        return nil
+    }
+
+    // below MinRequests the failures do not open the circuit
+    require.NoError(t, request(outcomeFailure))
+    require.NoError(t, request(outcomeFailure))
+    require.NoError(t, request(outcomeSuccess))
+    require.NoError(t, request(outcomeIgnored))
+    require.Equal(t, StateClosed, b.State())
+    require.NoError(t, request(outcomeSuccess))
+    require.Equal(t, StateOpen, b.State())
+
+    err := request(outcomeSuccess)
+    require.True(t, errors.Is(err, ErrCircuitOpen))
+    var openErr *CircuitOpenError
+    require.True(t, errors.As(err, &openErr))
+    require.Equal(t, clock.now.Add(time.Minute), openErr.RetryAt)
+
+    // after the cool-down a limited number of trial requests is let through
+    clock.now = clock.now.Add(time.Minute)
+    require.Equal(t, StateHalfOpen, b.State())
+    first, err := b.allow()
+    require.NoError(t, err)
+    second, err := b.allow()
+    require.NoError(t, err)
+    _, err = b.allow()
+    require.True(t, errors.Is(err, ErrCircuitOpen))
+    b.done(first, outcomeSuccess)
+    b.done(second, outcomeFailure)
+    require.Equal(t, StateOpen, b.State())
+
+    clock.now = clock.now.Add(time.Minute)
+    require.NoError(t, request(outcomeSuccess))
+    require.NoError(t, request(outcomeSuccess))
+    require.Equal(t, StateClosed, b.State())
+
+    stats := b.Stats()
+    require.Equal(t, int64(2), stats.Opened)
+    require.Equal(t, int64(2), stats.Rejected)
+    require.Equal(t, []string{
+        "closed->open", "open->half-open", "half-open->open", "open->half-open", "half-open->closed",
+    }, changes)
+}
+
+func TestCircuitBreaker_Window(t *testing.T) {
+    clock := &fakeClock{now: time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)}
+    b := NewCircuitBreaker(BreakerConfig{MinRequests: 2, Window: time.Minute})
+    b.now = clock.Now
+    b.expiry = b.windowEnd(clock.now)
+
+    generation, err := b.allow()
+    require.NoError(t, err)
+    b.done(generation, outcomeFailure)
+
+    // the failure of the previous window is forgotten
+    clock.now = clock.now.Add(time.Minute)
+    generation, err = b.allow()
+    require.NoError(t, err)
+    b.done(generation, outcomeFailure)
+    require.Equal(t, StateClosed, b.State())
+    require.Equal(t, int64(1), b.Stats().Failures)
+}
+
+func TestClient_CircuitBreaker(t *testing.T) {
+    ctx := context.Background()
+
+    var requests int64
+    testServer := httptest.NewServer(
+        http.HandlerFunc(
+            func(writer http.ResponseWriter, req *http.Request) {
+                atomic.AddInt64(&requests, 1)
+                writer.WriteHeader(http.StatusServiceUnavailable)
+            },
+        ),
+    )
+    defer testServer.Close()
+
+    breaker := NewCircuitBreaker(BreakerConfig{MinRequests: 3, CoolDown: time.Hour})
+    client := NewWithURL(testServer.URL, "apiKey",
+        WithCircuitBreaker(breaker),
+        WithRetry(RetryPolicy{MaxAttempts: 5}),
+    )
+
+    // the retries stop as soon as the circuit opens
+    _, err := client.Info(ctx, "tt0110475")
+    require.True(t, errors.Is(err, ErrCircuitOpen))
+    require.Equal(t, int64(3), atomic.LoadInt64(&requests))
+    require.Equal(t, StateOpen, breaker.State())
+
+    _, err = client.Info(ctx, "tt0110475")
+    require.True(t, errors.Is(err, ErrCircuitOpen))
+    require.Equal(t, int64(3), atomic.LoadInt64(&requests))
+}
+
+func TestOutcome(t *testing.T) {
+    ctx := context.Background()
+    cancelled, cancel := context.WithCancel(ctx)
+    cancel()
+
+    require.Equal(t, outcomeSuccess, outcome(ctx, nil))
+    require.Equal(t, outcomeSuccess, outcome(ctx, ErrNotFound))
+    require.Equal(t, outcomeFailure, outcome(ctx, ErrServer))
+    require.Equal(t, outcomeFailure, outcome(ctx, ErrTooManyRequests))
+    require.Equal(t, outcomeFailure, outcome(ctx, transportError{method: http.MethodGet, err: errors.New("reset")}))
+    require.Equal(t, outcomeIgnored, outcome(ctx, ErrQuotaExhausted))
+    require.Equal(t, outcomeIgnored, outcome(cancelled, transportError{method: http.MethodGet, err: context.Canceled}))
+}
@@ -0,0 +1,209 @@
+package omdb
+
+import (
+    "context"
+    "crypto/sha256"
+    "encoding/hex"
+    "encoding/json"
+    "errors"
+    "fmt"
+    "io/ioutil"
+    "net/url"
+    "os"
+    "path/filepath"
+    "strings"
+    "sync"
+    "sync/atomic"
+    "time"
+)
+
+// CacheConfig configures how long responses are served from a DiskCache.
+type CacheConfig struct {
+    // TTL is how long a response is served without asking the omdbapi again.
+    TTL time.Duration
+    // StaleWhileRevalidate is how long after the TTL a response is still
+    // served while it is refreshed in the background.
+    StaleWhileRevalidate time.Duration
+    // NegativeTTL is how long a not found response is served, 0 disables caching them.
+    NegativeTTL time.Duration
+    // RevalidateTimeout bounds a background refresh, defaulting to a minute.
+    RevalidateTimeout time.Duration
+}
+
+// CacheStats counts how requests were served by a DiskCache.
+type CacheStats struct {
+    Hits          int64
+    StaleHits     int64
+    NegativeHits  int64
+    Misses        int64
+    Revalidations int64
+}
+
+// DiskCache stores omdbapi responses on disk, one file per request. A single
+// DiskCache can be shared between clients, Close stops its background refreshes.
+type DiskCache struct {
+    dir    string
+    config CacheConfig
+    now    func() time.Time
+    stats  CacheStats
+
+    // ctx is the parent of the background refreshes, cancelled by Close.
+    ctx    context.Context
+    cancel context.CancelFunc
+    wg     sync.WaitGroup
+
+    mu           sync.Mutex
+    revalidating map[string]bool
+}
+
+// cacheEntry is a cached response as stored on disk.
+type cacheEntry struct {
+    Key      string    `json:"key"`
+    Stored   time.Time `json:"stored"`
+    NotFound string    `json:"notFound,omitempty"`
+    Body     []byte    `json:"body,omitempty"`
+}
+
+// NewDiskCache stores responses in dir, creating it when needed.
+func NewDiskCache(dir string, config CacheConfig) (*DiskCache, error) {
+    err := os.MkdirAll(dir, 0755)
+    if err != nil {
+        return nil, err
+    }
+    if config.RevalidateTimeout == 0 {
+        config.RevalidateTimeout = time.Minute
+    }
+    ctx, cancel := context.WithCancel(context.Background())
+    return &DiskCache{
+        dir:          dir,
+        config:       config,
+        now:          time.Now,
+        ctx:          ctx,
+        cancel:       cancel,
+        revalidating: make(map[string]bool),
+    }, nil
+}
+
+// Close cancels the background refreshes in progress and waits for them to
+// end. Stale entries served afterwards are no longer refreshed.
+func (d *DiskCache) Close() error {
+    // cancelling under the lock keeps revalidate from starting a refresh Wait misses
+    d.mu.Lock()
+    d.cancel()
+    d.mu.Unlock()
+    d.wg.Wait()
+    return nil
+}
+
+// WithCache serves responses from cache when possible and stores every response in it.
+func WithCache(cache *DiskCache) Option {
+    return func(c *Client) {
+        c.cache = cache
+    }
+}
+
+// Stats returns how requests were served so far.
+func (d *DiskCache) Stats() CacheStats {
+    return CacheStats{
+        Hits:          atomic.LoadInt64(&d.stats.Hits),
+        StaleHits:     atomic.LoadInt64(&d.stats.StaleHits),
+        NegativeHits:  atomic.LoadInt64(&d.stats.NegativeHits),
+        Misses:        atomic.LoadInt64(&d.stats.Misses),
+        Revalidations: atomic.LoadInt64(&d.stats.Revalidations),
+    }
+}
+
+// cached serves the request from the cache if possible, fetching and storing it otherwise.
+func (c Client) cached(ctx context.Context, values url.Values) ([]byte, error) {
+    if c.cache == nil {
+        return c.fetch(ctx, values)
+    }
+
+    key := values.Encode()
+    entry, ok := c.cache.load(key)
+    if ok {
+        age := c.cache.now().Sub(entry.Stored)
+        switch {
+        case entry.NotFound != "" && age < c.cache.config.NegativeTTL:
+            atomic.AddInt64(&c.cache.stats.NegativeHits, 1)
+            return nil, fmt.Errorf("%w: %s", ErrNotFound, entry.NotFound)
+        case entry.NotFound == "" && age < c.cache.config.TTL:
+            atomic.AddInt64(&c.cache.stats.Hits, 1)
+            return entry.Body, nil
+        case entry.NotFound == "" && age < c.cache.config.TTL+c.cache.config.StaleWhileRevalidate:
+            atomic.AddInt64(&c.cache.stats.StaleHits, 1)
+            c.revalidate(key, values)
+            return entry.Body, nil
+        }
+    }
+
+    atomic.AddInt64(&c.cache.stats.Misses, 1)
+    return c.fetchAndStore(ctx, key, values)
+}
+
+// revalidate refreshes a stale entry in the background, once per key at a time.
+// The refresh is detached from the request that found the entry stale, it is
+// bounded by RevalidateTimeout and cancelled by Close.
+func (c Client) revalidate(key string, values url.Values) {
+    c.cache.mu.Lock()
+    if c.cache.ctx.Err() != nil || c.cache.revalidating[key] {
+        c.cache.mu.Unlock()
+        return
+    }
+    c.cache.revalidating[key] = true
+    c.cache.wg.Add(1)
+    c.cache.mu.Unlock()
+
+    atomic.AddInt64(&c.cache.stats.Revalidations, 1)
+    go func() {
+        defer c.cache.wg.Done()
+        defer func() {
+            c.cache.mu.Lock()
+            delete(c.cache.revalidating, key)
+            c.cache.mu.Unlock()
+        }()
+        ctx, cancel := context.WithTimeout(c.cache.ctx, c.cache.config.RevalidateTimeout)
+        defer cancel()
+        c.fetchAndStore(ctx, key, values)
+    }()
+}
+
+func (c Client) fetchAndStore(ctx context.Context, key string, values url.Values) ([]byte, error) {
+    body, err := c.fetch(ctx, values)
+    switch {
+    case err == nil:
+        c.cache.store(cacheEntry{Key: key, Stored: c.cache.now(), Body: body})
+    case errors.Is(err, ErrNotFound) && c.cache.config.NegativeTTL > 0:
+        message := strings.TrimPrefix(err.Error(), ErrNotFound.Error()+": ")
+        c.cache.store(cacheEntry{Key: key, Stored: c.cache.now(), NotFound: message})
+    }
+    return body, err
+}
+
+// path returns the file of the entry with the given key.
+func (d *DiskCache) path(key string) string {
+    sum := sha256.Sum256([]byte(key))
+    return filepath.Join(d.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+func (d *DiskCache) load(key string) (cacheEntry, bool) {
+    data, err := ioutil.ReadFile(d.path(key))
+    if err != nil {
+        return cacheEntry{}, false
+    }
+    var entry cacheEntry
+    err = json.Unmarshal(data, &entry)
+    if err != nil || entry.Key != key {
+        return cacheEntry{}, false
+    }
+    return entry, true
+}
+
+// store writes entry to disk. Failing to cache a response does not fail the request.
+func (d *DiskCache) store(entry cacheEntry) {
+    data, err := json.Marshal(entry)
+    if err != nil {
+        return
+    }
+    writeFile(d.path(entry.Key), data)
+}
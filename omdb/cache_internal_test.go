@@ -0,0 +1,160 @@
+package omdb
+
+import (
+    "context"
+    "errors"
+    "io/ioutil"
+    "net/http"
+    "net/http/httptest"
+    "os"
+    "sync/atomic"
+    "testing"
+    "time"
+
+    "github.com/stretchr/testify/require"
+)
+
+func TestDiskCache(t *testing.T) {
+    ctx := context.Background()
+    var requests int32
+    testServer := httptest.NewServer(
+        http.HandlerFunc(
+            func(writer http.ResponseWriter, req *http.Request) {
+                n := atomic.AddInt32(&requests, 1)
+                if req.URL.Query().Get("i") == "tt9999999" {
+                    _, _ = writer.Write([]byte(`{"Response":"False","Error":"Incorrect IMDb ID."}`))
+                    return
+                }
+                if n == 1 {
+                    _, _ = writer.Write([]byte(`{"Title":"Blacksmith Scene","imdbID":"tt0000005","Response":"True"}`))
+                    return
+                }
+                _, _ = writer.Write([]byte(`{"Title":"Blacksmith Scene (revalidated)","imdbID":"tt0000005","Response":"True"}`))
+            },
+        ),
+    )
+    defer testServer.Close()
+
+    dir, err := ioutil.TempDir(os.TempDir(), "testCache-")
+    require.NoError(t, err)
+    defer os.RemoveAll(dir)
+
+    clock := &fakeClock{now: time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)}
+    cache, err := NewDiskCache(dir, CacheConfig{
+        TTL:                  time.Hour,
+        StaleWhileRevalidate: time.Hour,
+        NegativeTTL:          time.Minute,
+    })
+    require.NoError(t, err)
+    cache.now = clock.Now
+
+    client := NewWithURL(testServer.URL, "apiKey", WithCache(cache))
+
+    item, err := client.Info(ctx, "tt0000005")
+    require.NoError(t, err)
+    require.Equal(t, "Blacksmith Scene", item.Title)
+
+    item, err = client.Info(ctx, "tt0000005")
+    require.NoError(t, err)
+    require.Equal(t, "Blacksmith Scene", item.Title)
+    require.Equal(t, int32(1), atomic.LoadInt32(&requests))
+
+    // other parameters are cached separately
+    _, err = client.Info(ctx, "tt0000005", WithPlot(PlotFull))
+    require.NoError(t, err)
+    require.Equal(t, int32(2), atomic.LoadInt32(&requests))
+
+    // stale entries are served while they are refreshed
+    clock.now = clock.now.Add(90 * time.Minute)
+    item, err = client.Info(ctx, "tt0000005")
+    require.NoError(t, err)
+    require.Equal(t, "Blacksmith Scene", item.Title)
+    require.Eventually(t, func() bool {
+        entry, ok := cache.load("i=tt0000005")
+        return ok && entry.Stored.Equal(clock.now)
+    }, time.Second, time.Millisecond)
+
+    item, err = client.Info(ctx, "tt0000005")
+    require.NoError(t, err)
+    require.Equal(t, "Blacksmith Scene (revalidated)", item.Title)
+    require.Equal(t, int32(3), atomic.LoadInt32(&requests))
+
+    // not found responses are cached for the negative TTL
+    _, err = client.Info(ctx, "tt9999999")
+    require.True(t, errors.Is(err, ErrNotFound))
+    _, err = client.Info(ctx, "tt9999999")
+    require.EqualError(t, err, "not found: Incorrect IMDb ID.")
+    require.True(t, errors.Is(err, ErrNotFound))
+    require.Equal(t, int32(4), atomic.LoadInt32(&requests))
+
+    clock.now = clock.now.Add(2 * time.Minute)
+    _, err = client.Info(ctx, "tt9999999")
+    require.True(t, errors.Is(err, ErrNotFound))
+    require.Equal(t, int32(5), atomic.LoadInt32(&requests))
+
+    require.Equal(t, CacheStats{
+        Hits:          2,
+        StaleHits:     1,
+        NegativeHits:  1,
+        Misses:        4,
+        Revalidations: 1,
+    }, cache.Stats())
+}
+
+func TestDiskCache_RevalidateClose(t *testing.T) {
+    var requests int32
+    started := make(chan struct{})
+    cancelled := make(chan struct{})
+    testServer := httptest.NewServer(
+        http.HandlerFunc(
+            func(writer http.ResponseWriter, req *http.Request) {
+                atomic.AddInt32(&requests, 1)
+                close(started)
+                <-req.Context().Done()
+                close(cancelled)
+            },
+        ),
+    )
+    defer testServer.Close()
+
+    dir, err := ioutil.TempDir(os.TempDir(), "testCache-")
+    require.NoError(t, err)
+    defer os.RemoveAll(dir)
+
+    clock := &fakeClock{now: time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)}
+    cache, err := NewDiskCache(dir, CacheConfig{TTL: time.Hour, StaleWhileRevalidate: time.Hour})
+    require.NoError(t, err)
+    cache.now = clock.Now
+    cache.store(cacheEntry{Key: "i=tt0000005", Stored: clock.now, Body: []byte(`{"Title":"Blacksmith Scene","imdbID":"tt0000005","Response":"True"}`)})
+
+    client := NewWithURL(testServer.URL, "apiKey", WithCache(cache), WithRetry(RetryPolicy{MaxAttempts: 1}))
+
+    // the stale entry is served, and its refresh outlives the context of the caller
+    clock.now = clock.now.Add(90 * time.Minute)
+    ctx, cancel := context.WithCancel(context.Background())
+    item, err := client.Info(ctx, "tt0000005")
+    require.NoError(t, err)
+    require.Equal(t, "Blacksmith Scene", item.Title)
+    <-started
+    cancel()
+
+    select {
+    case <-cancelled:
+        require.FailNow(t, "revalidation cancelled with the context of the caller")
+    case <-time.After(50 * time.Millisecond):
+    }
+
+    // Close cancels the refresh and waits for it
+    require.NoError(t, cache.Close())
+    select {
+    case <-cancelled:
+    case <-time.After(time.Second):
+        require.FailNow(t, "revalidation not cancelled by Close")
+    }
+
+    // stale entries are no longer refreshed after Close
+    _, err = client.Info(context.Background(), "tt0000005")
+    require.NoError(t, err)
+    require.Equal(t, int32(1), atomic.LoadInt32(&requests))
+    require.Equal(t, int64(1), cache.Stats().Revalidations)
+}
@@ -0,0 +1,83 @@
+package omdb
+
+import (
+    "bytes"
+    "encoding/json"
+    "encoding/xml"
+    "errors"
+    "fmt"
+    "net/http"
+    "strings"
+)
+
+var (
+    // ErrNotFound is returned when the omdbapi has no title matching the request.
+    ErrNotFound = errors.New("not found")
+    // ErrInvalidAPIKey is returned when the API key is missing, unknown or not activated.
+    ErrInvalidAPIKey = errors.New("invalid API key")
+    // ErrRequestLimitReached is returned once the daily limit of the API key is used up.
+    ErrRequestLimitReached = errors.New("request limit reached")
+    // ErrServer is returned when the omdbapi fails to handle the request.
+    ErrServer = errors.New("server error")
+    // ErrTooManyRequests is returned when the omdbapi asks to slow down.
+    ErrTooManyRequests = errors.New("too many requests")
+)
+
+// apiError holds the fields the omdbapi sets on failed requests.
+type apiError struct {
+    Response string `json:"Response" xml:"response,attr"`
+    Error    string `json:"Error"    xml:"error"`
+}
+
+// checkResponse maps a failed omdbapi response onto one of the sentinel errors.
+func checkResponse(status int, body []byte) error {
+    if status >= http.StatusInternalServerError {
+        return fmt.Errorf("%w: %d %s", ErrServer, status, http.StatusText(status))
+    }
+    if status == http.StatusTooManyRequests {
+        return fmt.Errorf("%w: %d %s", ErrTooManyRequests, status, http.StatusText(status))
+    }
+
+    var apiErr apiError
+    var err error
+    if bytes.HasPrefix(bytes.TrimSpace(body), []byte("<")) {
+        err = xml.Unmarshal(body, &apiErr)
+    } else {
+        err = json.Unmarshal(body, &apiErr)
+    }
+    if err == nil && apiErr.Response == "False" {
+        return classifyError(apiErr.Error)
+    }
+    if status != http.StatusOK {
+        return fmt.Errorf("unexpected response: %d %s", status, http.StatusText(status))
+    }
+    return nil
+}
+
+// classifyError maps the `Error` message of the omdbapi onto one of the sentinel errors.
+func classifyError(message string) error {
+    lower := strings.ToLower(message)
+    switch {
+    case strings.Contains(lower, "not found"), strings.Contains(lower, "incorrect imdb id"):
+        return fmt.Errorf("%w: %s", ErrNotFound, message)
+    case strings.Contains(lower, "api key"):
+        return fmt.Errorf("%w: %s", ErrInvalidAPIKey, message)
+    case strings.Contains(lower, "limit reached"):
+        return fmt.Errorf("%w: %s", ErrRequestLimitReached, message)
+    }
+    return errors.New(message)
+}
+
+// transportError is a failure to send a request or to read its response.
+type transportError struct {
+    method string
+    err    error
+}
+
+func (e transportError) Error() string {
+    return e.err.Error()
+}
+
+func (e transportError) Unwrap() error {
+    return e.err
+}
@@ -0,0 +1,61 @@
+package omdb
+
+import (
+    "context"
+    "errors"
+    "net/url"
+    "sync"
+)
+
+// flightGroup coalesces identical requests in flight, so that only the first
+// one is sent and the others wait for its response.
+type flightGroup struct {
+    mu    sync.Mutex
+    calls map[string]*flight
+}
+
+// flight is a request in flight, body and err are set before done is closed.
+type flight struct {
+    done chan struct{}
+    body []byte
+    err  error
+}
+
+// shared serves the request through the cache, sharing the response with
+// identical requests already in flight. Coalescing happens before the cache, so
+// a single cache lookup, rate limiter token and quota unit is used per flight.
+func (c Client) shared(ctx context.Context, values url.Values) ([]byte, error) {
+    key := values.Encode()
+    for {
+        c.flights.mu.Lock()
+        if f, ok := c.flights.calls[key]; ok {
+            c.flights.mu.Unlock()
+            select {
+            case <-f.done:
+            case <-ctx.Done():
+                return nil, ctx.Err()
+            }
+            if contextError(f.err) && ctx.Err() == nil {
+                // the context of the first request ended, but not ours
+                continue
+            }
+            return f.body, f.err
+        }
+
+        f := &flight{done: make(chan struct{})}
+        c.flights.calls[key] = f
+        c.flights.mu.Unlock()
+
+        f.body, f.err = c.cached(ctx, values)
+
+        c.flights.mu.Lock()
+        delete(c.flights.calls, key)
+        c.flights.mu.Unlock()
+        close(f.done)
+        return f.body, f.err
+    }
+}
+
+func contextError(err error) bool {
+    return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
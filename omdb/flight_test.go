@@ -0,0 +1,142 @@
+package omdb_test
+
+import (
+    "context"
+    "errors"
+    "net/http"
+    "net/http/httptest"
+    "sync"
+    "sync/atomic"
+    "testing"
+    "time"
+
+    "github.com/stretchr/testify/require"
+
+    "Azarc/omdb"
+)
+
+func TestInfo_SharedInFlight(t *testing.T) {
+    var requests int32
+    release := make(chan struct{})
+    testServer := httptest.NewServer(
+        http.HandlerFunc(
+            func(writer http.ResponseWriter, req *http.Request) {
+                atomic.AddInt32(&requests, 1)
+                <-release
+                _, _ = writer.Write([]byte(`{"Title":"The Mask","imdbID":"tt0110475","Response":"True"}`))
+            },
+        ),
+    )
+    defer testServer.Close()
+
+    // a single unit of quota is enough for every caller
+    quota, err := omdb.NewQuota(1, "")
+    require.NoError(t, err)
+    client := omdb.NewWithURL(testServer.URL, "apiKey",
+        omdb.WithQuota(quota),
+        omdb.WithRateLimiter(omdb.NewRateLimiter(1, 1)),
+    )
+
+    const callers = 10
+    var wg sync.WaitGroup
+    items := make([]omdb.Item, callers)
+    errs := make([]error, callers)
+    for i := 0; i < callers; i++ {
+        wg.Add(1)
+        go func(i int) {
+            defer wg.Done()
+            items[i], errs[i] = client.Info(context.Background(), "tt0110475")
+        }(i)
+    }
+    time.Sleep(50 * time.Millisecond)
+    close(release)
+    wg.Wait()
+
+    require.Equal(t, int32(1), atomic.LoadInt32(&requests))
+    for i := range items {
+        require.NoError(t, errs[i])
+        require.Equal(t, "The Mask", items[i].Title)
+    }
+
+    // different queries are not shared
+    _, err = client.Info(context.Background(), "tt0110475", omdb.WithPlot(omdb.PlotFull))
+    require.True(t, errors.Is(err, omdb.ErrQuotaExhausted))
+}
+
+func TestInfo_SharedInFlight_FirstCancelled(t *testing.T) {
+    var requests int32
+    started := make(chan struct{}, 2)
+    testServer := httptest.NewServer(
+        http.HandlerFunc(
+            func(writer http.ResponseWriter, req *http.Request) {
+                if atomic.AddInt32(&requests, 1) == 1 {
+                    started <- struct{}{}
+                    <-req.Context().Done()
+                    return
+                }
+                _, _ = writer.Write([]byte(`{"Title":"The Mask","imdbID":"tt0110475","Response":"True"}`))
+            },
+        ),
+    )
+    defer testServer.Close()
+
+    client := omdb.NewWithURL(testServer.URL, "apiKey")
+
+    ctx, cancel := context.WithCancel(context.Background())
+    first := make(chan error)
+    go func() {
+        _, err := client.Info(ctx, "tt0110475")
+        first <- err
+    }()
+    <-started
+
+    second := make(chan error)
+    go func() {
+        item, err := client.Info(context.Background(), "tt0110475")
+        if err == nil && item.Title != "The Mask" {
+            err = errors.New("unexpected item " + item.Title)
+        }
+        second <- err
+    }()
+    time.Sleep(20 * time.Millisecond)
+    cancel()
+
+    require.True(t, errors.Is(<-first, context.Canceled))
+    // the waiting caller sends the request again instead of failing with the context of the first
+    require.NoError(t, <-second)
+    require.Equal(t, int32(2), atomic.LoadInt32(&requests))
+}
+
+func TestInfo_SharedInFlight_FirstCancelledDuringRetry(t *testing.T) {
+    var requests int32
+    testServer := newFailingServer(&requests, serverError)
+    defer testServer.Close()
+
+    client := omdb.NewWithURL(testServer.URL, "apiKey",
+        omdb.WithRetry(omdb.RetryPolicy{MaxAttempts: 2, BaseDelay: time.Minute}),
+    )
+
+    ctx, cancel := context.WithCancel(context.Background())
+    first := make(chan error)
+    go func() {
+        _, err := client.Info(ctx, "tt0000005")
+        first <- err
+    }()
+    for atomic.LoadInt32(&requests) == 0 {
+        time.Sleep(time.Millisecond)
+    }
+
+    second := make(chan error)
+    go func() {
+        _, err := client.Info(context.Background(), "tt0000005")
+        second <- err
+    }()
+    time.Sleep(20 * time.Millisecond)
+    cancel()
+
+    // the first caller reports its own cancellation rather than the server error it was backing off from
+    err := <-first
+    require.True(t, errors.Is(err, context.Canceled), err)
+    require.NoError(t, <-second)
+    require.Equal(t, int32(2), atomic.LoadInt32(&requests))
+}
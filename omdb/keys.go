@@ -0,0 +1,134 @@
+package omdb
+
+import (
+    "errors"
+    "sync"
+    "time"
+)
+
+// ErrNoAPIKeys is returned without sending a request once every key of a KeyPool is retired.
+var ErrNoAPIKeys = errors.New("no API key available")
+
+// KeyUsage reports how a key of a KeyPool was used.
+type KeyUsage struct {
+    Key      string
+    Requests int
+    // RetiredUntil is set while the key is retired, together with the error that retired it.
+    RetiredUntil time.Time
+    RetiredBy    error
+}
+
+// KeyPool rotates requests over several API keys. A key is retired until
+// midnight UTC once the omdbapi reports its request limit was reached or that
+// it is invalid. A single KeyPool can be shared between clients.
+type KeyPool struct {
+    mu   sync.Mutex
+    keys []*KeyUsage
+    next int
+    now  func() time.Time
+}
+
+// NewKeyPool rotates over keys in the given order.
+func NewKeyPool(keys ...string) *KeyPool {
+    p := &KeyPool{now: time.Now}
+    for _, key := range keys {
+        p.keys = append(p.keys, &KeyUsage{Key: key})
+    }
+    return p
+}
+
+// WithKeyPool sends requests with the keys of p. A key given to New is added
+// to p, after the keys p already holds.
+func WithKeyPool(p *KeyPool) Option {
+    return func(c *Client) {
+        c.keys = p
+    }
+}
+
+// add puts key in rotation after the other keys, unless p already holds it.
+func (p *KeyPool) add(key string) {
+    p.mu.Lock()
+    defer p.mu.Unlock()
+    for _, usage := range p.keys {
+        if usage.Key == key {
+            return
+        }
+    }
+    p.keys = append(p.keys, &KeyUsage{Key: key})
+}
+
+// Usage reports the usage of every key in the order they were given.
+func (p *KeyPool) Usage() []KeyUsage {
+    p.mu.Lock()
+    defer p.mu.Unlock()
+    p.reinstate()
+
+    usage := make([]KeyUsage, len(p.keys))
+    for i, key := range p.keys {
+        usage[i] = *key
+    }
+    return usage
+}
+
+// Available returns the number of keys that are not retired.
+func (p *KeyPool) Available() int {
+    p.mu.Lock()
+    defer p.mu.Unlock()
+    p.reinstate()
+
+    available := 0
+    for _, key := range p.keys {
+        if key.RetiredUntil.IsZero() {
+            available++
+        }
+    }
+    return available
+}
+
+// acquire returns the next key that is not retired.
+func (p *KeyPool) acquire() (string, error) {
+    p.mu.Lock()
+    defer p.mu.Unlock()
+    p.reinstate()
+
+    for range p.keys {
+        key := p.keys[p.next]
+        p.next = (p.next + 1) % len(p.keys)
+        if key.RetiredUntil.IsZero() {
+            key.Requests++
+            return key.Key, nil
+        }
+    }
+    return "", ErrNoAPIKeys
+}
+
+// retire stops using key until midnight UTC.
+func (p *KeyPool) retire(key string, err error) {
+    p.mu.Lock()
+    defer p.mu.Unlock()
+
+    now := p.now().UTC()
+    midnight := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, time.UTC)
+    for _, usage := range p.keys {
+        if usage.Key == key {
+            usage.RetiredUntil = midnight
+            usage.RetiredBy = err
+        }
+    }
+}
+
+// reinstate puts keys back in rotation once their retirement is over.
+func (p *KeyPool) reinstate() {
+    now := p.now()
+    for _, key := range p.keys {
+        if !key.RetiredUntil.IsZero() && !now.Before(key.RetiredUntil) {
+            key.RetiredUntil = time.Time{}
+            key.RetiredBy = nil
+        }
+    }
+}
+
+// keyError reports whether err is caused by the key the request was sent with.
+func keyError(err error) bool {
+    return errors.Is(err, ErrRequestLimitReached) || errors.Is(err, ErrInvalidAPIKey)
+}
@@ -0,0 +1,107 @@
+package omdb_test
+
+import (
+    "context"
+    "errors"
+    "net/http"
+    "net/http/httptest"
+    "testing"
+
+    "github.com/stretchr/testify/require"
+
+    "Azarc/omdb"
+)
+
+func TestKeyPool(t *testing.T) {
+    var keys []string
+    testServer := httptest.NewServer(
+        http.HandlerFunc(
+            func(writer http.ResponseWriter, req *http.Request) {
+                key := req.URL.Query().Get("apikey")
+                keys = append(keys, key)
+                switch key {
+                case "limited":
+                    writer.WriteHeader(http.StatusUnauthorized)
+                    _, _ = writer.Write([]byte(`{"Response":"False","Error":"Request limit reached!"}`))
+                case "invalid":
+                    writer.WriteHeader(http.StatusUnauthorized)
+                    _, _ = writer.Write([]byte(`{"Response":"False","Error":"Invalid API key!"}`))
+                default:
+                    _, _ = writer.Write([]byte(`{"Title":"Blacksmith Scene","imdbID":"tt0000005","Response":"True"}`))
+                }
+            },
+        ),
+    )
+    defer testServer.Close()
+
+    pool := omdb.NewKeyPool("first", "limited", "second", "invalid")
+    client := omdb.NewWithURL(testServer.URL, "", omdb.WithKeyPool(pool))
+
+    for i := 0; i < 5; i++ {
+        _, err := client.Info(context.Background(), "tt0000005")
+        require.NoError(t, err)
+    }
+    require.Equal(t, []string{"first", "limited", "second", "invalid", "first", "second", "first"}, keys)
+    require.Equal(t, 2, pool.Available())
+
+    usage := pool.Usage()
+    require.Len(t, usage, 4)
+    require.Equal(t, "first", usage[0].Key)
+    require.Equal(t, 3, usage[0].Requests)
+    require.True(t, usage[0].RetiredUntil.IsZero())
+    require.Equal(t, "limited", usage[1].Key)
+    require.Equal(t, 1, usage[1].Requests)
+    require.False(t, usage[1].RetiredUntil.IsZero())
+    require.True(t, errors.Is(usage[1].RetiredBy, omdb.ErrRequestLimitReached))
+    require.True(t, errors.Is(usage[3].RetiredBy, omdb.ErrInvalidAPIKey))
+}
+
+func TestKeyPool_AllRetired(t *testing.T) {
+    requests := 0
+    testServer := httptest.NewServer(
+        http.HandlerFunc(
+            func(writer http.ResponseWriter, req *http.Request) {
+                requests++
+                writer.WriteHeader(http.StatusUnauthorized)
+                _, _ = writer.Write([]byte(`{"Response":"False","Error":"Request limit reached!"}`))
+            },
+        ),
+    )
+    defer testServer.Close()
+
+    pool := omdb.NewKeyPool("a", "b")
+    client := omdb.NewWithURL(testServer.URL, "", omdb.WithKeyPool(pool))
+
+    _, err := client.Info(context.Background(), "tt0000005")
+    require.True(t, errors.Is(err, omdb.ErrRequestLimitReached))
+    require.Equal(t, 2, requests)
+
+    _, err = client.Info(context.Background(), "tt0000005")
+    require.Equal(t, omdb.ErrNoAPIKeys, err)
+    require.Equal(t, 2, requests)
+}
+
+func TestKeyPool_NewKey(t *testing.T) {
+    var keys []string
+    testServer := httptest.NewServer(
+        http.HandlerFunc(
+            func(writer http.ResponseWriter, req *http.Request) {
+                keys = append(keys, req.URL.Query().Get("apikey"))
+                _, _ = writer.Write([]byte(`{"Title":"Blacksmith Scene","imdbID":"tt0000005","Response":"True"}`))
+            },
+        ),
+    )
+    defer testServer.Close()
+
+    // the key given to New joins the pool once
+    pool := omdb.NewKeyPool("first")
+    client := omdb.NewWithURL(testServer.URL, "second", omdb.WithKeyPool(pool))
+    omdb.NewWithURL(testServer.URL, "first", omdb.WithKeyPool(pool))
+
+    for i := 0; i < 3; i++ {
+        _, err := client.Info(context.Background(), "tt0000005")
+        require.NoError(t, err)
+    }
+    require.Equal(t, []string{"first", "second", "first"}, keys)
+    require.Len(t, pool.Usage(), 2)
+}
@@ -0,0 +1,238 @@
+package omdb
+
+import (
+    "context"
+    "encoding/json"
+    "errors"
+    "io/ioutil"
+    "os"
+    "path/filepath"
+    "sync"
+    "time"
+)
+
+// ErrQuotaExhausted is returned without sending a request once a Quota is used up.
+var ErrQuotaExhausted = errors.New("quota exhausted")
+
+// RateLimiter is a token bucket limiting the number of requests per second.
+// A single RateLimiter can be shared between clients.
+type RateLimiter struct {
+    mu     sync.Mutex
+    rate   float64
+    burst  float64
+    tokens float64
+    last   time.Time
+    now    func() time.Time
+}
+
+// NewRateLimiter allows rate requests per second on average and bursts of up to burst requests.
+func NewRateLimiter(rate float64, burst int) *RateLimiter {
+    if burst < 1 {
+        burst = 1
+    }
+    return &RateLimiter{
+        rate:   rate,
+        burst:  float64(burst),
+        tokens: float64(burst),
+        now:    time.Now,
+    }
+}
+
+// Wait blocks until a request may be sent or ctx is done.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+    delay := l.reserve()
+    if delay <= 0 {
+        return nil
+    }
+
+    timer := time.NewTimer(delay)
+    defer timer.Stop()
+    select {
+    case <-timer.C:
+        return nil
+    case <-ctx.Done():
+        l.cancel()
+        return ctx.Err()
+    }
+}
+
+// reserve takes a token, returning how long to wait until it is available.
+func (l *RateLimiter) reserve() time.Duration {
+    l.mu.Lock()
+    defer l.mu.Unlock()
+
+    now := l.now()
+    if !l.last.IsZero() {
+        l.tokens += now.Sub(l.last).Seconds() * l.rate
+        if l.tokens > l.burst {
+            l.tokens = l.burst
+        }
+    }
+    l.last = now
+
+    l.tokens--
+    if l.tokens >= 0 {
+        return 0
+    }
+    return time.Duration(-l.tokens / l.rate * float64(time.Second))
+}
+
+// cancel returns the token of a reservation that was not used.
+func (l *RateLimiter) cancel() {
+    l.mu.Lock()
+    defer l.mu.Unlock()
+    l.tokens++
+}
+
+// quotaState is the usage of a Quota as persisted in its state file.
+type quotaState struct {
+    Day  string `json:"day"`
+    Used int    `json:"used"`
+}
+
+// Quota limits the number of requests per day, resetting at midnight UTC.
+// A single Quota can be shared between clients.
+type Quota struct {
+    mu    sync.Mutex
+    limit int
+    path  string
+    state quotaState
+    now   func() time.Time
+}
+
+// NewQuota allows limit requests per day. When path is not empty the usage is
+// loaded from and saved to that file, so the quota holds across runs. The
+// directory of path is created when needed.
+func NewQuota(limit int, path string) (*Quota, error) {
+    q := &Quota{
+        limit: limit,
+        path:  path,
+        now:   time.Now,
+    }
+    if path == "" {
+        return q, nil
+    }
+    err := os.MkdirAll(filepath.Dir(path), 0755)
+    if err != nil {
+        return nil, err
+    }
+
+    data, err := ioutil.ReadFile(path)
+    if os.IsNotExist(err) {
+        return q, nil
+    }
+    if err != nil {
+        return nil, err
+    }
+    err = json.Unmarshal(data, &q.state)
+    if err != nil {
+        return nil, err
+    }
+    return q, nil
+}
+
+// Used returns the number of requests sent today.
+func (q *Quota) Used() int {
+    q.mu.Lock()
+    defer q.mu.Unlock()
+    q.rollover()
+    return q.state.Used
+}
+
+// Remaining returns the number of requests left today.
+func (q *Quota) Remaining() int {
+    q.mu.Lock()
+    defer q.mu.Unlock()
+    q.rollover()
+    if q.state.Used >= q.limit {
+        return 0
+    }
+    return q.limit - q.state.Used
+}
+
+// take counts a request against the quota, failing with ErrQuotaExhausted once it is used up.
+func (q *Quota) take() error {
+    q.mu.Lock()
+    defer q.mu.Unlock()
+    q.rollover()
+    if q.state.Used >= q.limit {
+        return ErrQuotaExhausted
+    }
+    q.state.Used++
+    err := q.save()
+    if err != nil {
+        // the request is not sent, so it is not counted either
+        q.state.Used--
+    }
+    return err
+}
+
+// release gives back a request counted by take that was not sent.
+func (q *Quota) release() error {
+    q.mu.Lock()
+    defer q.mu.Unlock()
+    q.rollover()
+    if q.state.Used == 0 {
+        return nil
+    }
+    q.state.Used--
+    return q.save()
+}
+
+func releaseQuotas(quotas []*Quota) {
+    for _, q := range quotas {
+        q.release()
+    }
+}
+
+// exhaust uses up the quota for the rest of the day, e.g. once the omdbapi reports the limit was reached.
+func (q *Quota) exhaust() error {
+    q.mu.Lock()
+    defer q.mu.Unlock()
+    q.rollover()
+    if q.state.Used >= q.limit {
+        return nil
+    }
+    q.state.Used = q.limit
+    return q.save()
+}
+
+// rollover resets the usage when the day changed.
+func (q *Quota) rollover() {
+    day := q.now().UTC().Format("2006-01-02")
+    if q.state.Day != day {
+        q.state = quotaState{Day: day}
+    }
+}
+
+// save persists the state, if the quota has a state file.
+func (q *Quota) save() error {
+    if q.path == "" {
+        return nil
+    }
+    data, err := json.Marshal(q.state)
+    if err != nil {
+        return err
+    }
+    return writeFile(q.path, data)
+}
+
+// writeFile writes data through a temporary file so a crash cannot leave path truncated.
+func writeFile(path string, data []byte) error {
+    tmp, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".tmp-")
+    if err != nil {
+        return err
+    }
+    _, err = tmp.Write(data)
+    if err != nil {
+        tmp.Close()
+        os.Remove(tmp.Name())
+        return err
+    }
+    err = tmp.Close()
+    if err != nil {
+        os.Remove(tmp.Name())
+        return err
+    }
+    return os.Rename(tmp.Name(), path)
+}
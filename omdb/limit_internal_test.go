@@ -0,0 +1,114 @@
+package omdb
+
+import (
+    "context"
+    "errors"
+    "io/ioutil"
+    "os"
+    "path/filepath"
+    "testing"
+    "time"
+
+    "github.com/stretchr/testify/require"
+)
+
+type fakeClock struct {
+    now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+    return c.now
+}
+
+func TestRateLimiter_Reserve(t *testing.T) {
+    clock := &fakeClock{now: time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)}
+    l := NewRateLimiter(2, 2)
+    l.now = clock.Now
+
+    require.Equal(t, time.Duration(0), l.reserve())
+    require.Equal(t, time.Duration(0), l.reserve())
+    require.Equal(t, 500*time.Millisecond, l.reserve())
+    require.Equal(t, time.Second, l.reserve())
+
+    clock.now = clock.now.Add(2 * time.Second)
+    require.Equal(t, time.Duration(0), l.reserve())
+
+    clock.now = clock.now.Add(time.Hour)
+    require.Equal(t, time.Duration(0), l.reserve())
+    require.Equal(t, time.Duration(0), l.reserve())
+    require.Equal(t, 500*time.Millisecond, l.reserve())
+}
+
+func TestRateLimiter_WaitCancelled(t *testing.T) {
+    l := NewRateLimiter(0.001, 1)
+    require.NoError(t, l.Wait(context.Background()))
+
+    ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+    defer cancel()
+    require.Equal(t, context.DeadlineExceeded, l.Wait(ctx))
+    require.InDelta(t, 0, l.tokens, 0.01)
+}
+
+func TestQuota(t *testing.T) {
+    dir, err := ioutil.TempDir(os.TempDir(), "testQuota-")
+    require.NoError(t, err)
+    defer os.RemoveAll(dir)
+    path := filepath.Join(dir, "quota.json")
+
+    clock := &fakeClock{now: time.Date(2020, time.January, 1, 23, 0, 0, 0, time.UTC)}
+    q, err := NewQuota(2, path)
+    require.NoError(t, err)
+    q.now = clock.Now
+
+    require.NoError(t, q.take())
+    require.Equal(t, 1, q.Remaining())
+
+    reloaded, err := NewQuota(2, path)
+    require.NoError(t, err)
+    reloaded.now = clock.Now
+    require.Equal(t, 1, reloaded.Used())
+    require.NoError(t, reloaded.take())
+    require.Equal(t, ErrQuotaExhausted, reloaded.take())
+    require.Equal(t, 0, reloaded.Remaining())
+
+    clock.now = clock.now.Add(2 * time.Hour)
+    require.Equal(t, 0, reloaded.Used())
+    require.NoError(t, reloaded.exhaust())
+    require.Equal(t, ErrQuotaExhausted, reloaded.take())
+
+    data, err := ioutil.ReadFile(path)
+    require.NoError(t, err)
+    require.JSONEq(t, `{"day":"2020-01-02","used":2}`, string(data))
+}
+
+func TestQuota_SaveError(t *testing.T) {
+    dir, err := ioutil.TempDir(os.TempDir(), "testQuota-")
+    require.NoError(t, err)
+    defer os.RemoveAll(dir)
+
+    q, err := NewQuota(10, filepath.Join(dir, "state", "quota.json"))
+    require.NoError(t, err)
+    require.NoError(t, q.take())
+    require.NoError(t, os.RemoveAll(filepath.Join(dir, "state")))
+
+    // a request failing to be counted is not counted
+    require.Error(t, q.take())
+    require.Equal(t, 1, q.Used())
+}
+
+func TestKeyFailed_ExhaustError(t *testing.T) {
+    dir, err := ioutil.TempDir(os.TempDir(), "testQuota-")
+    require.NoError(t, err)
+    defer os.RemoveAll(dir)
+
+    // the quota cannot be saved once its directory is removed
+    q, err := NewQuota(10, filepath.Join(dir, "missing", "quota.json"))
+    require.NoError(t, err)
+    require.NoError(t, os.RemoveAll(filepath.Join(dir, "missing")))
+    c := NewWithURL("", "apiKey", WithQuota(q))
+
+    err = c.keyFailed("apiKey", ErrRequestLimitReached)
+    require.True(t, errors.Is(err, ErrRequestLimitReached), err)
+    require.Contains(t, err.Error(), "saving the exhausted quota")
+    require.Equal(t, 0, q.Remaining())
+}
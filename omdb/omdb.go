@@ -3,64 +3,248 @@ package omdb
 import (
     "context"
     "encoding/json"
+    "encoding/xml"
+    "errors"
+    "fmt"
+    "io/ioutil"
     "net/http"
     "net/url"
+    "time"
 )
 
-// http://www.omdbapi.com/?apikey=43a201a
+// Item is the raw response of the omdbapi, see Item.Typed for parsed values.
 type Item struct {
-    Title    string `json:"Title"`
-    Year     string `json:"Year"` // toint
-    Rated    string `json:"Rated"`
-    Released string `json:"Released"` // totime
-    Runtime  string `json:"Runtime"`
-    Genre    string `json:"Genre"` // toslice
-    Director string `json:"Director"`
-    Writer   string `json:"Writer"`
-    Actors   string `json:"Actors"` // toslice
-    Plot     string `json:"Info"`
-    Language string `json:"Language"`
-    Country  string `json:"Country"`
-    Awards   string `json:"Awards"`
-    Poster   string `json:"Poster"`
-    // "Ratings":[{"Source":"Internet Movie Database","Value":"6.1/10"}],"Metascore":"N/A","imdbRating":"6.1","imdbVotes":"2,223","imdbID":"tt0000005","Type":"movie","DVD":"N/A","BoxOffice":"N/A","Production":"N/A","Website":"N/A","Response":"True"}
+    Title        string   `json:"Title"        xml:"title,attr"`
+    Year         string   `json:"Year"         xml:"year,attr"`
+    Rated        string   `json:"Rated"        xml:"rated,attr"`
+    Released     string   `json:"Released"     xml:"released,attr"`
+    Runtime      string   `json:"Runtime"      xml:"runtime,attr"`
+    Genre        string   `json:"Genre"        xml:"genre,attr"`
+    Director     string   `json:"Director"     xml:"director,attr"`
+    Writer       string   `json:"Writer"       xml:"writer,attr"`
+    Actors       string   `json:"Actors"       xml:"actors,attr"`
+    Plot         string   `json:"Plot"         xml:"plot,attr"`
+    Language     string   `json:"Language"     xml:"language,attr"`
+    Country      string   `json:"Country"      xml:"country,attr"`
+    Awards       string   `json:"Awards"       xml:"awards,attr"`
+    Poster       string   `json:"Poster"       xml:"poster,attr"`
+    Ratings      []Rating `json:"Ratings"      xml:"ratings>rating"`
+    Metascore    string   `json:"Metascore"    xml:"metascore,attr"`
+    ImdbRating   string   `json:"imdbRating"   xml:"imdbRating,attr"`
+    ImdbVotes    string   `json:"imdbVotes"    xml:"imdbVotes,attr"`
+    ImdbID       string   `json:"imdbID"       xml:"imdbID,attr"`
+    Type         string   `json:"Type"         xml:"type,attr"`
+    DVD          string   `json:"DVD"          xml:"dvd,attr,omitempty"`
+    BoxOffice    string   `json:"BoxOffice"    xml:"boxOffice,attr,omitempty"`
+    Production   string   `json:"Production"   xml:"production,attr,omitempty"`
+    Website      string   `json:"Website"      xml:"website,attr,omitempty"`
+    // TotalSeasons is only set for series.
+    TotalSeasons string   `json:"totalSeasons" xml:"totalSeasons,attr,omitempty"`
+    Response     string   `json:"Response"     xml:"-"`
+}
+
+// Rating is the score given to a title by a single source, e.g. `"Rotten Tomatoes"` and `"61%"`.
+type Rating struct {
+    Source string `json:"Source" xml:"source,attr"`
+    Value  string `json:"Value"  xml:"value,attr"`
 }
 
 type Client struct {
     url        string
-    apikey     string
+    keys       *KeyPool
     httpClient *http.Client
+    limiter    *RateLimiter
+    quotas     []*Quota
+    retry      RetryPolicy
+    cache      *DiskCache
+    breaker    *CircuitBreaker
+    flights    *flightGroup
+    format     Format
+    timeout    time.Duration
+    userAgent  string
+    transport  http.RoundTripper
+    middleware []Middleware
 }
 
-func New(apikey string) Client {
-    return NewWithURL( "https://www.omdbapi.com",  apikey)
+// Option configures a Client.
+type Option func(c *Client)
+
+// WithRateLimiter makes every request wait for l.
+func WithRateLimiter(l *RateLimiter) Option {
+    return func(c *Client) {
+        c.limiter = l
+    }
+}
+
+// WithQuota counts every request against q, failing with ErrQuotaExhausted once it is used up.
+// Multiple quotas may be set, e.g. a daily one and one per run.
+func WithQuota(q *Quota) Option {
+    return func(c *Client) {
+        c.quotas = append(c.quotas, q)
+    }
 }
 
-func NewWithURL(url string, apikey string) Client {
-    return Client{
+// New sends requests to https://www.omdbapi.com with apikey. With WithKeyPool
+// apikey, if not empty, is added to the pool.
+func New(apikey string, opts ...Option) Client {
+    return NewWithURL( "https://www.omdbapi.com",  apikey, opts...)
+}
+
+func NewWithURL(url string, apikey string, opts ...Option) Client {
+    c := Client{
         url:        url,
-        apikey:     apikey,
+        keys:       NewKeyPool(apikey),
         httpClient: &http.Client{},
+        flights:    &flightGroup{calls: make(map[string]*flight)},
+    }
+    defaultKeys := c.keys
+    for _, opt := range opts {
+        opt(&c)
     }
+    if c.keys != defaultKeys && apikey != "" {
+        c.keys.add(apikey)
+    }
+    c.buildHTTPClient()
+    return c
 }
 
-func (c Client) Info(ctx context.Context, id string) (Item, error) {
-    values := url.Values{
-        "apikey":[]string{c.apikey},
+// Info looks up the title with the given imdbID, e.g. `tt0110475`.
+func (c Client) Info(ctx context.Context, id string, opts ...QueryOption) (Item, error) {
+    values := query(url.Values{
         "i":[]string{id},
+    }, opts)
+    return c.item(ctx, values)
+}
+
+// Title looks up the title best matching the given name. Use WithYear and WithType to narrow the lookup.
+func (c Client) Title(ctx context.Context, title string, opts ...QueryOption) (Item, error) {
+    values := query(url.Values{
+        "t":[]string{title},
+    }, opts)
+    return c.item(ctx, values)
+}
+
+// item looks up a single title in the format of the client.
+func (c Client) item(ctx context.Context, values url.Values) (Item, error) {
+    if c.format == FormatXML {
+        values.Set("r", string(FormatXML))
     }
-    req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url, nil)
+
+    var item Item
+    err := c.get(ctx, values, &item)
     if err != nil {
         return Item{}, err
     }
-    req.URL.RawQuery = values.Encode()
+    return item, nil
+}
+
+// get sends the query in values to the omdbapi and decodes a successful response into out.
+// Identical concurrent queries share a single request.
+func (c Client) get(ctx context.Context, values url.Values, out interface{}) error {
+    body, err := c.shared(ctx, values)
+    if err != nil {
+        return err
+    }
+    if values.Get("r") == string(FormatXML) {
+        return xml.Unmarshal(body, out)
+    }
+    return json.Unmarshal(body, out)
+}
+
+// do sends a single request and returns the body of a successful response.
+// The Retry-After delay requested by the omdbapi, if any, is returned alongside errors.
+func (c Client) do(ctx context.Context, values url.Values) (body []byte, retryAfter time.Duration, err error) {
+    apikey, done, err := c.admit(ctx)
+    if err != nil {
+        return nil, 0, err
+    }
+    defer func() {
+        done(err)
+    }()
+
+    req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url, nil)
+    if err != nil {
+        return nil, 0, err
+    }
+
+    query := url.Values{"apikey": []string{apikey}}
+    for key, value := range values {
+        query[key] = value
+    }
+    req.URL.RawQuery = query.Encode()
 
     res, err := c.httpClient.Do(req)
     if err != nil {
-        return Item{}, err
+        return nil, 0, transportError{method: req.Method, err: err}
     }
+    defer res.Body.Close()
 
-    var item Item
-    err = json.NewDecoder(res.Body).Decode(&item)
-    return item, err
+    body, err = ioutil.ReadAll(res.Body)
+    if err != nil {
+        return nil, 0, transportError{method: req.Method, err: err}
+    }
+
+    err = checkResponse(res.StatusCode, body)
+    err = c.keyFailed(apikey, err)
+    if err != nil {
+        return nil, parseRetryAfter(res.Header.Get("Retry-After")), err
+    }
+    return body, 0, nil
+}
+
+// keyFailed retires apikey when err was caused by it, and exhausts the quotas
+// once the request limit of every key is reached. It returns err, annotated
+// when the exhausted quota could not be saved.
+func (c Client) keyFailed(apikey string, err error) error {
+    if keyError(err) {
+        c.keys.retire(apikey, err)
+    }
+    if errors.Is(err, ErrRequestLimitReached) && c.keys.Available() == 0 {
+        for _, q := range c.quotas {
+            saveErr := q.exhaust()
+            if saveErr != nil {
+                err = fmt.Errorf("%w (saving the exhausted quota: %v)", err, saveErr)
+            }
+        }
+    }
+    return err
+}
+
+// admit passes the circuit breaker, rate limiter and quotas of the client and
+// acquires the key to send a request with. done must be called with the
+// outcome of the request, so that the circuit breaker counts it.
+func (c Client) admit(ctx context.Context) (apikey string, done func(error), err error) {
+    done = func(error) {}
+    if c.breaker != nil {
+        generation, err := c.breaker.allow()
+        if err != nil {
+            return "", nil, err
+        }
+        done = func(err error) {
+            c.breaker.done(generation, outcome(ctx, err))
+        }
+    }
+
+    if c.limiter != nil {
+        err = c.limiter.Wait(ctx)
+        if err != nil {
+            done(err)
+            return "", nil, err
+        }
+    }
+    for i, q := range c.quotas {
+        err = q.take()
+        if err != nil {
+            releaseQuotas(c.quotas[:i])
+            done(err)
+            return "", nil, err
+        }
+    }
+    apikey, err = c.keys.acquire()
+    if err != nil {
+        releaseQuotas(c.quotas)
+        done(err)
+        return "", nil, err
+    }
+    return apikey, done, nil
 }
@@ -2,10 +2,12 @@ package omdb_test
 
 import (
     "context"
+    "errors"
     "fmt"
     "math/rand"
     "net/http"
     "net/http/httptest"
+    "net/url"
     "testing"
 
     "github.com/stretchr/testify/require"
@@ -26,7 +28,7 @@ func TestInfo(t *testing.T) {
                 require.Equal(t, id, req.Form.Get("i"))
                 require.Equal(t, apikey, req.Form.Get("apikey"))
 
-                _, err = writer.Write([]byte(`{"Title":"Blacksmith Scene","Year":"1893","Rated":"Unrated","Released":"09 May 1893","Runtime":"1 min","Genre":"Short, Comedy","Director":"William K.L. Dickson","Writer":"N/A","Actors":"Charles Kayser, John Ott","Info":"Three men hammer on an anvil and pass a bottle of beer around.","Language":"None","Country":"USA","Awards":"1 win.","Poster":"https://m.media-amazon.com/images/M/MV5BNDg0ZDg0YWYtYzMwYi00ZjVlLWI5YzUtNzBkNjlhZWM5ODk5XkEyXkFqcGdeQXVyNDk0MDg4NDk@._V1_SX300.jpg","Ratings":[{"Source":"Internet Movie Database","Value":"6.1/10"}],"Metascore":"N/A","imdbRating":"6.1","imdbVotes":"2,223","imdbID":"tt0000005","Type":"movie","DVD":"N/A","BoxOffice":"N/A","Production":"N/A","Website":"N/A","Response":"True"}`))
+                _, err = writer.Write([]byte(`{"Title":"Blacksmith Scene","Year":"1893","Rated":"Unrated","Released":"09 May 1893","Runtime":"1 min","Genre":"Short, Comedy","Director":"William K.L. Dickson","Writer":"N/A","Actors":"Charles Kayser, John Ott","Plot":"Three men hammer on an anvil and pass a bottle of beer around.","Language":"None","Country":"USA","Awards":"1 win.","Poster":"https://m.media-amazon.com/images/M/MV5BNDg0ZDg0YWYtYzMwYi00ZjVlLWI5YzUtNzBkNjlhZWM5ODk5XkEyXkFqcGdeQXVyNDk0MDg4NDk@._V1_SX300.jpg","Ratings":[{"Source":"Internet Movie Database","Value":"6.1/10"}],"Metascore":"N/A","imdbRating":"6.1","imdbVotes":"2,223","imdbID":"tt0000005","Type":"movie","DVD":"N/A","BoxOffice":"N/A","Production":"N/A","Website":"N/A","Response":"True"}`))
                 require.NoError(t, err)
             },
         ),
@@ -51,5 +53,240 @@ func TestInfo(t *testing.T) {
         Country:  "USA",
         Awards:   "1 win.",
         Poster:   "https://m.media-amazon.com/images/M/MV5BNDg0ZDg0YWYtYzMwYi00ZjVlLWI5YzUtNzBkNjlhZWM5ODk5XkEyXkFqcGdeQXVyNDk0MDg4NDk@._V1_SX300.jpg",
+        Ratings: []omdb.Rating{
+            {Source: "Internet Movie Database", Value: "6.1/10"},
+        },
+        Metascore:  "N/A",
+        ImdbRating: "6.1",
+        ImdbVotes:  "2,223",
+        ImdbID:     "tt0000005",
+        Type:       "movie",
+        DVD:        "N/A",
+        BoxOffice:  "N/A",
+        Production: "N/A",
+        Website:    "N/A",
+        Response:   "True",
     }, item)
 }
+
+func TestInfo_Errors(t *testing.T) {
+    ctx := context.Background()
+
+    testTable := []struct {
+        name          string
+        status        int
+        body          string
+        expectedErr   error
+        expectedError string
+    }{
+        {
+            name:          "not found",
+            status:        http.StatusOK,
+            body:          `{"Response":"False","Error":"Incorrect IMDb ID."}`,
+            expectedErr:   omdb.ErrNotFound,
+            expectedError: "not found: Incorrect IMDb ID.",
+        },
+        {
+            name:          "movie not found",
+            status:        http.StatusOK,
+            body:          `{"Response":"False","Error":"Movie not found!"}`,
+            expectedErr:   omdb.ErrNotFound,
+            expectedError: "not found: Movie not found!",
+        },
+        {
+            name:          "invalid key",
+            status:        http.StatusUnauthorized,
+            body:          `{"Response":"False","Error":"Invalid API key!"}`,
+            expectedErr:   omdb.ErrInvalidAPIKey,
+            expectedError: "invalid API key: Invalid API key!",
+        },
+        {
+            name:          "no key",
+            status:        http.StatusUnauthorized,
+            body:          `{"Response":"False","Error":"No API key provided."}`,
+            expectedErr:   omdb.ErrInvalidAPIKey,
+            expectedError: "invalid API key: No API key provided.",
+        },
+        {
+            name:          "request limit",
+            status:        http.StatusUnauthorized,
+            body:          `{"Response":"False","Error":"Request limit reached!"}`,
+            expectedErr:   omdb.ErrRequestLimitReached,
+            expectedError: "request limit reached: Request limit reached!",
+        },
+        {
+            name:          "server",
+            status:        http.StatusServiceUnavailable,
+            body:          `<html>Service Unavailable</html>`,
+            expectedErr:   omdb.ErrServer,
+            expectedError: "server error: 503 Service Unavailable",
+        },
+        {
+            name:          "unexpected status",
+            status:        http.StatusTeapot,
+            body:          ``,
+            expectedError: "unexpected response: 418 I'm a teapot",
+        },
+    }
+
+    for _, test := range testTable {
+        t.Run(test.name, func(t *testing.T) {
+            testServer := httptest.NewServer(
+                http.HandlerFunc(
+                    func(writer http.ResponseWriter, req *http.Request) {
+                        writer.WriteHeader(test.status)
+                        _, err := writer.Write([]byte(test.body))
+                        require.NoError(t, err)
+                    },
+                ),
+            )
+            defer testServer.Close()
+
+            client := omdb.NewWithURL(testServer.URL, "apiKey")
+
+            item, err := client.Info(ctx, "tt0000005")
+            require.EqualError(t, err, test.expectedError)
+            if test.expectedErr != nil {
+                require.True(t, errors.Is(err, test.expectedErr))
+            }
+            require.Equal(t, omdb.Item{}, item)
+        })
+    }
+}
+
+func TestInfo_Plot(t *testing.T) {
+    ctx := context.Background()
+
+    testTable := []struct {
+        name         string
+        opts         []omdb.QueryOption
+        expectedPlot string
+    }{
+        {
+            name: "default",
+        },
+        {
+            name:         "short",
+            opts:         []omdb.QueryOption{omdb.WithPlot(omdb.PlotShort)},
+            expectedPlot: "short",
+        },
+        {
+            name:         "full",
+            opts:         []omdb.QueryOption{omdb.WithPlot(omdb.PlotFull)},
+            expectedPlot: "full",
+        },
+    }
+
+    for _, test := range testTable {
+        t.Run(test.name, func(t *testing.T) {
+            testServer := httptest.NewServer(
+                http.HandlerFunc(
+                    func(writer http.ResponseWriter, req *http.Request) {
+                        require.Equal(t, test.expectedPlot, req.URL.Query().Get("plot"))
+                        _, err := writer.Write([]byte(`{"Title":"Blacksmith Scene","Plot":"Three men hammer on an anvil.","imdbID":"tt0000005","Response":"True"}`))
+                        require.NoError(t, err)
+                    },
+                ),
+            )
+            defer testServer.Close()
+
+            client := omdb.NewWithURL(testServer.URL, "apiKey")
+
+            item, err := client.Info(ctx, "tt0000005", test.opts...)
+            require.NoError(t, err)
+            require.Equal(t, "Three men hammer on an anvil.", item.Plot)
+        })
+    }
+}
+
+func TestTitle(t *testing.T) {
+    ctx := context.Background()
+
+    testTable := []struct {
+        name          string
+        opts          []omdb.QueryOption
+        expectedQuery url.Values
+    }{
+        {
+            name: "title only",
+            expectedQuery: url.Values{
+                "apikey": []string{"apiKey"},
+                "t":      []string{"The Mask"},
+            },
+        },
+        {
+            name: "year and type",
+            opts: []omdb.QueryOption{omdb.WithYear(1994), omdb.WithType("movie")},
+            expectedQuery: url.Values{
+                "apikey": []string{"apiKey"},
+                "t":      []string{"The Mask"},
+                "y":      []string{"1994"},
+                "type":   []string{"movie"},
+            },
+        },
+    }
+
+    for _, test := range testTable {
+        t.Run(test.name, func(t *testing.T) {
+            testServer := httptest.NewServer(
+                http.HandlerFunc(
+                    func(writer http.ResponseWriter, req *http.Request) {
+                        require.Equal(t, test.expectedQuery, req.URL.Query())
+                        _, err := writer.Write([]byte(`{"Title":"The Mask","Year":"1994","imdbID":"tt0110475","Type":"movie","Response":"True"}`))
+                        require.NoError(t, err)
+                    },
+                ),
+            )
+            defer testServer.Close()
+
+            client := omdb.NewWithURL(testServer.URL, "apiKey")
+
+            item, err := client.Title(ctx, "The Mask", test.opts...)
+            require.NoError(t, err)
+            require.Equal(t, omdb.Item{
+                Title:    "The Mask",
+                Year:     "1994",
+                ImdbID:   "tt0110475",
+                Type:     "movie",
+                Response: "True",
+            }, item)
+        })
+    }
+}
+
+func TestInfo_Quota(t *testing.T) {
+    ctx := context.Background()
+    requests := 0
+    testServer := httptest.NewServer(
+        http.HandlerFunc(
+            func(writer http.ResponseWriter, req *http.Request) {
+                requests++
+                if requests == 2 {
+                    writer.WriteHeader(http.StatusUnauthorized)
+                    _, err := writer.Write([]byte(`{"Response":"False","Error":"Request limit reached!"}`))
+                    require.NoError(t, err)
+                    return
+                }
+                _, err := writer.Write([]byte(`{"Title":"Blacksmith Scene","imdbID":"tt0000005","Response":"True"}`))
+                require.NoError(t, err)
+            },
+        ),
+    )
+    defer testServer.Close()
+
+    quota, err := omdb.NewQuota(10, "")
+    require.NoError(t, err)
+    client := omdb.NewWithURL(testServer.URL, "apiKey", omdb.WithQuota(quota), omdb.WithRateLimiter(omdb.NewRateLimiter(1000, 1)))
+
+    _, err = client.Info(ctx, "tt0000005")
+    require.NoError(t, err)
+    require.Equal(t, 9, quota.Remaining())
+
+    _, err = client.Info(ctx, "tt0000005")
+    require.True(t, errors.Is(err, omdb.ErrRequestLimitReached))
+    require.Equal(t, 0, quota.Remaining())
+
+    _, err = client.Info(ctx, "tt0000005")
+    require.Equal(t, omdb.ErrQuotaExhausted, err)
+    require.Equal(t, 2, requests)
+}
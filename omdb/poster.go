@@ -0,0 +1,399 @@
+package omdb
+
+import (
+    "context"
+    "crypto/sha256"
+    "encoding/hex"
+    "errors"
+    "fmt"
+    "image"
+    _ "image/gif"
+    _ "image/jpeg"
+    _ "image/png"
+    "io"
+    "io/ioutil"
+    "net/http"
+    "net/url"
+    "os"
+    "path/filepath"
+    "strconv"
+    "strings"
+    "sync"
+)
+
+var (
+    // ErrNoPoster is returned for items without a poster.
+    ErrNoPoster = errors.New("no poster")
+    // ErrInvalidImage is returned when a downloaded poster is not a gif, jpeg or png image.
+    ErrInvalidImage = errors.New("invalid image")
+    // ErrPosterRefused is returned when the Poster API refuses the API key, e.g.
+    // one that is not a patron key.
+    ErrPosterRefused = errors.New("poster API refused the API key")
+)
+
+const posterAPIURL = "https://img.omdbapi.com"
+
+// PosterConfig configures a PosterStore.
+type PosterConfig struct {
+    // Concurrency limits the number of simultaneous downloads, defaulting to 4.
+    Concurrency int
+    // Height requests posters of this height from the Poster API, which
+    // requires a patron API key. When 0 the Poster url of the Item is downloaded.
+    // Poster API requests count against the limits of the client like lookups.
+    Height int
+    // PosterAPIURL overrides the url of the Poster API.
+    PosterAPIURL string
+}
+
+// PosterStore downloads posters into a directory. Images are stored once per
+// content under `objects/`, named by their sha256, and linked to imdbIDs
+// under `index/`. Interrupted downloads are resumed from `partial/`.
+type PosterStore struct {
+    dir    string
+    client Client
+    config PosterConfig
+    sem    chan struct{}
+
+    mu       sync.Mutex
+    inflight map[string]*posterCall
+}
+
+// posterCall is a download in progress, shared by every caller asking for the same poster.
+type posterCall struct {
+    done chan struct{}
+    path string
+    err  error
+}
+
+// PosterResult is the outcome of downloading the poster of a single Item.
+type PosterResult struct {
+    ImdbID string
+    Path   string
+    Err    error
+}
+
+// NewPosterStore stores posters in dir, creating it when needed. The client
+// provides the API keys and HTTP client used for the Poster API.
+func NewPosterStore(client Client, dir string, config PosterConfig) (*PosterStore, error) {
+    for _, sub := range []string{"objects", "index", "partial"} {
+        err := os.MkdirAll(filepath.Join(dir, sub), 0755)
+        if err != nil {
+            return nil, err
+        }
+    }
+    if config.Concurrency < 1 {
+        config.Concurrency = 4
+    }
+    if config.PosterAPIURL == "" {
+        config.PosterAPIURL = posterAPIURL
+    }
+    return &PosterStore{
+        dir:      dir,
+        client:   client,
+        config:   config,
+        sem:      make(chan struct{}, config.Concurrency),
+        inflight: make(map[string]*posterCall),
+    }, nil
+}
+
+// Lookup returns the path of the poster of imdbID, if it was downloaded before.
+func (s *PosterStore) Lookup(imdbID string) (string, bool) {
+    data, err := ioutil.ReadFile(s.indexPath(imdbID))
+    if err != nil {
+        return "", false
+    }
+    path := filepath.Join(s.dir, "objects", strings.TrimSpace(string(data)))
+    if _, err := os.Stat(path); err != nil {
+        return "", false
+    }
+    return path, true
+}
+
+// Download stores the poster of item and returns its path. Posters downloaded
+// before are not downloaded again, and concurrent calls for the same poster
+// share a single download, also when the poster is shared by several items.
+func (s *PosterStore) Download(ctx context.Context, item Item) (string, error) {
+    if path, ok := s.Lookup(item.ImdbID); ok {
+        return path, nil
+    }
+
+    source, err := s.source(item)
+    if err != nil {
+        return "", err
+    }
+    path, err := s.shared(ctx, source)
+    if err != nil {
+        return "", err
+    }
+    err = writeFile(s.indexPath(item.ImdbID), []byte(filepath.Base(path)))
+    if err != nil {
+        return "", err
+    }
+    return path, nil
+}
+
+// DownloadAll stores the posters of items, see Download. The results are in the order of items.
+func (s *PosterStore) DownloadAll(ctx context.Context, items []Item) []PosterResult {
+    results := make([]PosterResult, len(items))
+    wg := new(sync.WaitGroup)
+    wg.Add(len(items))
+    for i := range items {
+        iCopy := i
+        go func() {
+            defer wg.Done()
+            path, err := s.Download(ctx, items[iCopy])
+            results[iCopy] = PosterResult{ImdbID: items[iCopy].ImdbID, Path: path, Err: err}
+        }()
+    }
+    wg.Wait()
+    return results
+}
+
+// posterSource is where a poster is downloaded from. The url of the Poster API
+// lacks the API key, which is added to every request.
+type posterSource struct {
+    url       string
+    posterAPI bool
+}
+
+// key names the partial download of the source.
+func (p posterSource) key() string {
+    sum := sha256.Sum256([]byte(p.url))
+    return hex.EncodeToString(sum[:])
+}
+
+// shared downloads source, sharing the download with concurrent calls for the same source.
+func (s *PosterStore) shared(ctx context.Context, source posterSource) (string, error) {
+    key := source.key()
+    s.mu.Lock()
+    if call, ok := s.inflight[key]; ok {
+        s.mu.Unlock()
+        select {
+        case <-call.done:
+            return call.path, call.err
+        case <-ctx.Done():
+            return "", ctx.Err()
+        }
+    }
+    call := &posterCall{done: make(chan struct{})}
+    s.inflight[key] = call
+    s.mu.Unlock()
+
+    call.path, call.err = s.download(ctx, source)
+    close(call.done)
+
+    s.mu.Lock()
+    delete(s.inflight, key)
+    s.mu.Unlock()
+    return call.path, call.err
+}
+
+// download stores the image of source in objects and returns its path.
+func (s *PosterStore) download(ctx context.Context, source posterSource) (string, error) {
+    select {
+    case s.sem <- struct{}{}:
+        defer func() { <-s.sem }()
+    case <-ctx.Done():
+        return "", ctx.Err()
+    }
+
+    partial := filepath.Join(s.dir, "partial", source.key())
+    err := s.fetch(ctx, source, partial)
+    if err != nil {
+        return "", err
+    }
+
+    object, err := s.store(partial)
+    if err != nil {
+        return "", err
+    }
+    return filepath.Join(s.dir, "objects", object), nil
+}
+
+// source returns where to download the poster of item from.
+func (s *PosterStore) source(item Item) (posterSource, error) {
+    if s.config.Height == 0 {
+        if naString(item.Poster) == "" {
+            return posterSource{}, fmt.Errorf("%w: %s", ErrNoPoster, item.ImdbID)
+        }
+        return posterSource{url: item.Poster}, nil
+    }
+
+    values := url.Values{
+        "i": []string{item.ImdbID},
+        "h": []string{strconv.Itoa(s.config.Height)},
+    }
+    return posterSource{url: s.config.PosterAPIURL + "/?" + values.Encode(), posterAPI: true}, nil
+}
+
+// get requests source. Requests to the Poster API pass the circuit breaker,
+// rate limiter and quotas of the client and are sent with the next key of its
+// pool. A key refused by the Poster API stays in use for other requests.
+func (s *PosterStore) get(ctx context.Context, source posterSource, header http.Header) (*http.Response, error) {
+    if !source.posterAPI {
+        req, err := http.NewRequestWithContext(ctx, http.MethodGet, source.url, nil)
+        if err != nil {
+            return nil, err
+        }
+        req.Header = header
+        return s.client.httpClient.Do(req)
+    }
+
+    apikey, done, err := s.client.admit(ctx)
+    if err != nil {
+        return nil, err
+    }
+    target := source.url + "&" + url.Values{"apikey": []string{apikey}}.Encode()
+    req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+    if err != nil {
+        done(err)
+        return nil, err
+    }
+    req.Header = header
+    res, err := s.client.httpClient.Do(req)
+    if err != nil {
+        err = transportError{method: req.Method, err: err}
+        done(err)
+        return nil, err
+    }
+
+    if res.StatusCode == http.StatusUnauthorized || res.StatusCode == http.StatusForbidden {
+        body, _ := ioutil.ReadAll(res.Body)
+        res.Body.Close()
+        err = fmt.Errorf("%w: %d %s", ErrPosterRefused, res.StatusCode, strings.TrimSpace(string(body)))
+        done(err)
+        return nil, err
+    }
+    done(checkResponse(res.StatusCode, nil))
+    return res, nil
+}
+
+// fetch downloads source into partial, resuming from the bytes already in partial.
+// A download is only resumed with If-Range, so that it restarts when the image
+// changed since the bytes in partial were downloaded.
+func (s *PosterStore) fetch(ctx context.Context, source posterSource, partial string) error {
+    file, err := os.OpenFile(partial, os.O_CREATE|os.O_WRONLY, 0644)
+    if err != nil {
+        return err
+    }
+    defer file.Close()
+
+    offset, err := file.Seek(0, io.SeekEnd)
+    if err != nil {
+        return err
+    }
+
+    header := http.Header{}
+    validator, err := ioutil.ReadFile(partial + ".validator")
+    if offset > 0 && err == nil {
+        header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+        header.Set("If-Range", string(validator))
+    }
+
+    res, err := s.get(ctx, source, header)
+    if err != nil {
+        return err
+    }
+    defer res.Body.Close()
+
+    switch res.StatusCode {
+    case http.StatusPartialContent:
+        if !strings.HasPrefix(res.Header.Get("Content-Range"), fmt.Sprintf("bytes %d-", offset)) {
+            return fmt.Errorf("unexpected Content-Range %q resuming from %d", res.Header.Get("Content-Range"), offset)
+        }
+    case http.StatusRequestedRangeNotSatisfiable:
+        // the previous attempt already downloaded everything, store verifies the image
+        return nil
+    case http.StatusOK:
+        err = file.Truncate(0)
+        if err != nil {
+            return err
+        }
+        _, err = file.Seek(0, io.SeekStart)
+        if err != nil {
+            return err
+        }
+        err = saveValidator(partial, res.Header)
+        if err != nil {
+            return err
+        }
+    case http.StatusNotFound:
+        return fmt.Errorf("%w: %s", ErrNoPoster, source.url)
+    default:
+        return checkResponse(res.StatusCode, nil)
+    }
+
+    n, err := io.Copy(file, res.Body)
+    if err != nil {
+        return err
+    }
+    if res.ContentLength >= 0 && n != res.ContentLength {
+        return fmt.Errorf("%w: received %d of %d bytes", io.ErrUnexpectedEOF, n, res.ContentLength)
+    }
+    return nil
+}
+
+// saveValidator stores the ETag, or else the Last-Modified date, of a poster next
+// to partial, for resuming its download with If-Range. Without either header the
+// download cannot be resumed safely and restarts instead.
+func saveValidator(partial string, header http.Header) error {
+    validator := header.Get("ETag")
+    if validator == "" || strings.HasPrefix(validator, "W/") {
+        // If-Range requires a strong validator
+        validator = header.Get("Last-Modified")
+    }
+    if validator == "" {
+        err := os.Remove(partial + ".validator")
+        if os.IsNotExist(err) {
+            return nil
+        }
+        return err
+    }
+    return writeFile(partial+".validator", []byte(validator))
+}
+
+// store validates the downloaded image and moves it into objects, returning its name.
+func (s *PosterStore) store(partial string) (string, error) {
+    file, err := os.Open(partial)
+    if err != nil {
+        return "", err
+    }
+    // decode the whole image rather than its header, to reject truncated downloads
+    _, format, err := image.Decode(file)
+    if err != nil {
+        file.Close()
+        os.Remove(partial)
+        os.Remove(partial + ".validator")
+        return "", fmt.Errorf("%w: %v", ErrInvalidImage, err)
+    }
+
+    _, err = file.Seek(0, io.SeekStart)
+    if err != nil {
+        file.Close()
+        return "", err
+    }
+    hash := sha256.New()
+    _, err = io.Copy(hash, file)
+    file.Close()
+    if err != nil {
+        return "", err
+    }
+
+    os.Remove(partial + ".validator")
+    object := hex.EncodeToString(hash.Sum(nil)) + "." + format
+    path := filepath.Join(s.dir, "objects", object)
+    if _, err := os.Stat(path); err == nil {
+        // the same image is already stored for another title
+        return object, os.Remove(partial)
+    }
+    return object, os.Rename(partial, path)
+}
+
+// indexPath returns the file linking imdbID to its poster.
+func (s *PosterStore) indexPath(imdbID string) string {
+    name := imdbID
+    if s.config.Height != 0 {
+        name += "-h" + strconv.Itoa(s.config.Height)
+    }
+    return filepath.Join(s.dir, "index", name)
+}
@@ -0,0 +1,325 @@
+package omdb_test
+
+import (
+    "bytes"
+    "context"
+    "crypto/sha256"
+    "encoding/hex"
+    "errors"
+    "image"
+    "image/color"
+    "image/png"
+    "io/ioutil"
+    "net/http"
+    "net/http/httptest"
+    "os"
+    "path/filepath"
+    "strconv"
+    "sync"
+    "sync/atomic"
+    "testing"
+    "time"
+
+    "github.com/stretchr/testify/require"
+
+    "Azarc/omdb"
+)
+
+func testPNG(t *testing.T, c color.Color) []byte {
+    img := image.NewRGBA(image.Rect(0, 0, 30, 45))
+    for x := 0; x < 30; x++ {
+        for y := 0; y < 45; y++ {
+            img.Set(x, y, c)
+        }
+    }
+    var buf bytes.Buffer
+    require.NoError(t, png.Encode(&buf, img))
+    return buf.Bytes()
+}
+
+func tempDir(t *testing.T) string {
+    dir, err := ioutil.TempDir(os.TempDir(), "testPosters-")
+    require.NoError(t, err)
+    t.Cleanup(func() {
+        os.RemoveAll(dir)
+    })
+    return dir
+}
+
+func TestPosterStore_Download(t *testing.T) {
+    red := testPNG(t, color.RGBA{R: 255, A: 255})
+    blue := testPNG(t, color.RGBA{B: 255, A: 255})
+    var requests int32
+    testServer := httptest.NewServer(
+        http.HandlerFunc(
+            func(writer http.ResponseWriter, req *http.Request) {
+                atomic.AddInt32(&requests, 1)
+                switch req.URL.Path {
+                case "/red.png", "/red-again.png":
+                    http.ServeContent(writer, req, "poster.png", time.Time{}, bytes.NewReader(red))
+                case "/blue.png":
+                    http.ServeContent(writer, req, "poster.png", time.Time{}, bytes.NewReader(blue))
+                case "/html.png":
+                    _, _ = writer.Write([]byte("<html>not an image</html>"))
+                case "/truncated.png":
+                    _, _ = writer.Write(red[:len(red)-20])
+                default:
+                    http.NotFound(writer, req)
+                }
+            },
+        ),
+    )
+    defer testServer.Close()
+
+    dir := tempDir(t)
+    store, err := omdb.NewPosterStore(omdb.New("apiKey"), dir, omdb.PosterConfig{})
+    require.NoError(t, err)
+
+    ctx := context.Background()
+    results := store.DownloadAll(ctx, []omdb.Item{
+        {ImdbID: "tt0000001", Poster: testServer.URL + "/red.png"},
+        {ImdbID: "tt0000002", Poster: testServer.URL + "/blue.png"},
+        {ImdbID: "tt0000003", Poster: testServer.URL + "/red-again.png"},
+        {ImdbID: "tt0000004", Poster: testServer.URL + "/html.png"},
+        {ImdbID: "tt0000005", Poster: "N/A"},
+        {ImdbID: "tt0000006", Poster: testServer.URL + "/missing.png"},
+        {ImdbID: "tt0000007", Poster: testServer.URL + "/truncated.png"},
+    })
+    require.Len(t, results, 7)
+
+    redSum := sha256.Sum256(red)
+    redPath := filepath.Join(dir, "objects", hex.EncodeToString(redSum[:])+".png")
+    require.NoError(t, results[0].Err)
+    require.Equal(t, redPath, results[0].Path)
+    require.NoError(t, results[1].Err)
+    require.NotEqual(t, redPath, results[1].Path)
+    require.NoError(t, results[2].Err)
+    require.Equal(t, redPath, results[2].Path)
+    require.True(t, errors.Is(results[3].Err, omdb.ErrInvalidImage), results[3].Err)
+    require.True(t, errors.Is(results[4].Err, omdb.ErrNoPoster), results[4].Err)
+    require.True(t, errors.Is(results[5].Err, omdb.ErrNoPoster), results[5].Err)
+    require.True(t, errors.Is(results[6].Err, omdb.ErrInvalidImage), results[6].Err)
+
+    data, err := ioutil.ReadFile(redPath)
+    require.NoError(t, err)
+    require.Equal(t, red, data)
+
+    objects, err := ioutil.ReadDir(filepath.Join(dir, "objects"))
+    require.NoError(t, err)
+    require.Len(t, objects, 2)
+
+    // downloaded posters are served from disk
+    before := atomic.LoadInt32(&requests)
+    path, err := store.Download(ctx, omdb.Item{ImdbID: "tt0000001", Poster: testServer.URL + "/red.png"})
+    require.NoError(t, err)
+    require.Equal(t, redPath, path)
+    require.Equal(t, before, atomic.LoadInt32(&requests))
+
+    path, ok := store.Lookup("tt0000002")
+    require.True(t, ok)
+    require.Equal(t, results[1].Path, path)
+    _, ok = store.Lookup("tt0000004")
+    require.False(t, ok)
+}
+
+func TestPosterStore_Deduplicate(t *testing.T) {
+    red := testPNG(t, color.RGBA{R: 255, A: 255})
+    var requests int32
+    release := make(chan struct{})
+    testServer := httptest.NewServer(
+        http.HandlerFunc(
+            func(writer http.ResponseWriter, req *http.Request) {
+                atomic.AddInt32(&requests, 1)
+                <-release
+                _, _ = writer.Write(red)
+            },
+        ),
+    )
+    defer testServer.Close()
+
+    store, err := omdb.NewPosterStore(omdb.New("apiKey"), tempDir(t), omdb.PosterConfig{Concurrency: 1})
+    require.NoError(t, err)
+
+    // the same item several times, and other items sharing its poster
+    items := []omdb.Item{
+        {ImdbID: "tt0000001", Poster: testServer.URL + "/red.png"},
+        {ImdbID: "tt0000001", Poster: testServer.URL + "/red.png"},
+        {ImdbID: "tt0000001", Poster: testServer.URL + "/red.png"},
+        {ImdbID: "tt0000002", Poster: testServer.URL + "/red.png"},
+        {ImdbID: "tt0000003", Poster: testServer.URL + "/red.png"},
+    }
+    wg := new(sync.WaitGroup)
+    wg.Add(len(items))
+    for _, item := range items {
+        go func(item omdb.Item) {
+            defer wg.Done()
+            _, err := store.Download(context.Background(), item)
+            require.NoError(t, err)
+        }(item)
+    }
+    time.Sleep(50 * time.Millisecond)
+    close(release)
+    wg.Wait()
+
+    require.Equal(t, int32(1), atomic.LoadInt32(&requests))
+    for _, item := range items {
+        _, ok := store.Lookup(item.ImdbID)
+        require.True(t, ok, item.ImdbID)
+    }
+}
+
+func TestPosterStore_Resume(t *testing.T) {
+    red := testPNG(t, color.RGBA{R: 255, A: 255})
+    blue := testPNG(t, color.RGBA{B: 255, A: 255})
+
+    testTable := []struct {
+        name           string
+        etag           string
+        expected       []byte
+        expectedRanges []string
+    }{
+        {
+            name:           "unchanged",
+            etag:           `"red"`,
+            expected:       red,
+            expectedRanges: []string{"", "bytes=20-"},
+        },
+        {
+            name:           "changed",
+            etag:           `"blue"`,
+            expected:       blue,
+            expectedRanges: []string{"", "bytes=20-"},
+        },
+    }
+
+    for _, test := range testTable {
+        t.Run(test.name, func(t *testing.T) {
+            var ranges []string
+            testServer := httptest.NewServer(
+                http.HandlerFunc(
+                    func(writer http.ResponseWriter, req *http.Request) {
+                        ranges = append(ranges, req.Header.Get("Range"))
+                        if len(ranges) == 1 {
+                            // the first download is interrupted after 20 bytes of red
+                            writer.Header().Set("ETag", `"red"`)
+                            writer.Header().Set("Content-Length", strconv.Itoa(len(red)))
+                            _, _ = writer.Write(red[:20])
+                            writer.(http.Flusher).Flush()
+                            conn, _, err := writer.(http.Hijacker).Hijack()
+                            if err == nil {
+                                conn.Close()
+                            }
+                            return
+                        }
+                        data := red
+                        if test.etag == `"blue"` {
+                            data = blue
+                        }
+                        writer.Header().Set("ETag", test.etag)
+                        http.ServeContent(writer, req, "poster.png", time.Time{}, bytes.NewReader(data))
+                    },
+                ),
+            )
+            defer testServer.Close()
+
+            store, err := omdb.NewPosterStore(omdb.New("apiKey"), tempDir(t), omdb.PosterConfig{})
+            require.NoError(t, err)
+
+            item := omdb.Item{ImdbID: "tt0000001", Poster: testServer.URL + "/poster.png"}
+            _, err = store.Download(context.Background(), item)
+            require.Error(t, err)
+
+            path, err := store.Download(context.Background(), item)
+            require.NoError(t, err)
+            require.Equal(t, test.expectedRanges, ranges)
+
+            data, err := ioutil.ReadFile(path)
+            require.NoError(t, err)
+            require.Equal(t, test.expected, data)
+        })
+    }
+}
+
+func TestPosterStore_PosterAPI(t *testing.T) {
+    red := testPNG(t, color.RGBA{R: 255, A: 255})
+    testServer := httptest.NewServer(
+        http.HandlerFunc(
+            func(writer http.ResponseWriter, req *http.Request) {
+                require.Equal(t, "apiKey", req.URL.Query().Get("apikey"))
+                require.Equal(t, "tt0000001", req.URL.Query().Get("i"))
+                require.Equal(t, "600", req.URL.Query().Get("h"))
+                _, _ = writer.Write(red)
+            },
+        ),
+    )
+    defer testServer.Close()
+
+    dir := tempDir(t)
+    store, err := omdb.NewPosterStore(omdb.New("apiKey"), dir, omdb.PosterConfig{
+        Height:       600,
+        PosterAPIURL: testServer.URL,
+    })
+    require.NoError(t, err)
+
+    path, err := store.Download(context.Background(), omdb.Item{ImdbID: "tt0000001", Poster: "N/A"})
+    require.NoError(t, err)
+
+    data, err := ioutil.ReadFile(path)
+    require.NoError(t, err)
+    require.Equal(t, red, data)
+
+    _, err = os.Stat(filepath.Join(dir, "index", "tt0000001-h600"))
+    require.NoError(t, err)
+}
+
+func TestPosterStore_PosterAPIRefused(t *testing.T) {
+    var keys []string
+    testServer := httptest.NewServer(
+        http.HandlerFunc(
+            func(writer http.ResponseWriter, req *http.Request) {
+                keys = append(keys, req.URL.Query().Get("apikey"))
+                writer.WriteHeader(http.StatusUnauthorized)
+                _, _ = writer.Write([]byte("Error: Invalid API key!"))
+            },
+        ),
+    )
+    defer testServer.Close()
+
+    // a refused key stays in the pool for lookups
+    pool := omdb.NewKeyPool("basic", "apiKey")
+    store, err := omdb.NewPosterStore(omdb.New("", omdb.WithKeyPool(pool)), tempDir(t), omdb.PosterConfig{
+        Height:       600,
+        PosterAPIURL: testServer.URL,
+    })
+    require.NoError(t, err)
+
+    _, err = store.Download(context.Background(), omdb.Item{ImdbID: "tt0000001"})
+    require.True(t, errors.Is(err, omdb.ErrPosterRefused), err)
+    require.Equal(t, []string{"basic"}, keys)
+    require.Equal(t, 2, pool.Available())
+}
+
+func TestPosterStore_PosterAPIQuota(t *testing.T) {
+    red := testPNG(t, color.RGBA{R: 255, A: 255})
+    testServer := httptest.NewServer(
+        http.HandlerFunc(
+            func(writer http.ResponseWriter, req *http.Request) {
+                _, _ = writer.Write(red)
+            },
+        ),
+    )
+    defer testServer.Close()
+
+    quota, err := omdb.NewQuota(1, filepath.Join(tempDir(t), "quota.json"))
+    require.NoError(t, err)
+    store, err := omdb.NewPosterStore(omdb.New("apiKey", omdb.WithQuota(quota)), tempDir(t), omdb.PosterConfig{
+        Height:       600,
+        PosterAPIURL: testServer.URL,
+    })
+    require.NoError(t, err)
+
+    _, err = store.Download(context.Background(), omdb.Item{ImdbID: "tt0000001"})
+    require.NoError(t, err)
+    _, err = store.Download(context.Background(), omdb.Item{ImdbID: "tt0000002"})
+    require.True(t, errors.Is(err, omdb.ErrQuotaExhausted), err)
+}
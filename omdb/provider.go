@@ -0,0 +1,186 @@
+package omdb
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "net/url"
+    "strings"
+)
+
+// MetadataProvider looks up title metadata. Client implements it against the
+// omdbapi, other sources can be plugged in alongside it with CompositeProvider.
+// Searching for every title matching a name is not part of it and stays
+// specific to the omdbapi, see Client.Search.
+type MetadataProvider interface {
+    // Info looks up the title with the given imdbID, failing with ErrNotFound when it is unknown.
+    Info(ctx context.Context, id string, opts ...QueryOption) (Item, error)
+    // Title looks up the title best matching the given name, failing with ErrNotFound when there is none.
+    Title(ctx context.Context, title string, opts ...QueryOption) (Item, error)
+}
+
+var _ MetadataProvider = Client{}
+
+// CompositeProvider tries several providers in order, merging their results.
+type CompositeProvider struct {
+    providers []MetadataProvider
+}
+
+// NewCompositeProvider returns a provider asking providers in order. Fields a
+// provider leaves empty or "N/A" are filled from the following providers, which
+// are only asked while fields are still empty, and ratings are merged by source.
+// A lookup fails only when every provider fails.
+func NewCompositeProvider(providers ...MetadataProvider) CompositeProvider {
+    return CompositeProvider{providers: providers}
+}
+
+// Info looks up the title with the given imdbID from every provider needed to fill its fields.
+func (p CompositeProvider) Info(ctx context.Context, id string, opts ...QueryOption) (Item, error) {
+    return p.lookup(ctx, true, func(provider MetadataProvider) (Item, error) {
+        return provider.Info(ctx, id, opts...)
+    })
+}
+
+// Title looks up the title best matching the given name. Only results with the
+// imdbID of the first match are merged, so nothing is merged into a first match
+// without an imdbID and results without one are ignored.
+func (p CompositeProvider) Title(ctx context.Context, title string, opts ...QueryOption) (Item, error) {
+    return p.lookup(ctx, false, func(provider MetadataProvider) (Item, error) {
+        return provider.Title(ctx, title, opts...)
+    })
+}
+
+// lookup merges the results of fn for every provider. Results of a lookup by
+// imdbID describe the same title, other results only when their imdbIDs match.
+func (p CompositeProvider) lookup(ctx context.Context, byID bool, fn func(MetadataProvider) (Item, error)) (Item, error) {
+    var merged Item
+    found := false
+    var err error
+    for _, provider := range p.providers {
+        item, lookupErr := fn(provider)
+        if lookupErr != nil {
+            if ctx.Err() != nil {
+                return Item{}, lookupErr
+            }
+            // failures other than not found matter more to the caller, e.g. an invalid API key
+            if err == nil || errors.Is(err, ErrNotFound) && !errors.Is(lookupErr, ErrNotFound) {
+                err = lookupErr
+            }
+            continue
+        }
+
+        if !found {
+            merged = item
+            found = true
+        } else if byID || merged.ImdbID != "" && merged.ImdbID == item.ImdbID {
+            mergeItem(&merged, item)
+        }
+        if complete(merged) || !byID && merged.ImdbID == "" {
+            break
+        }
+    }
+    if !found && err == nil {
+        return Item{}, fmt.Errorf("%w: no metadata providers", ErrNotFound)
+    }
+    if !found {
+        return Item{}, err
+    }
+    return merged, nil
+}
+
+// stringFields returns pointers to the string fields of item that are merged.
+func stringFields(item *Item) []*string {
+    return []*string{
+        &item.Title, &item.Year, &item.Rated, &item.Released, &item.Runtime, &item.Genre,
+        &item.Director, &item.Writer, &item.Actors, &item.Plot, &item.Language, &item.Country,
+        &item.Awards, &item.Poster, &item.Metascore, &item.ImdbRating, &item.ImdbVotes,
+        &item.ImdbID, &item.Type, &item.DVD, &item.BoxOffice, &item.Production, &item.Website,
+    }
+}
+
+// mergeItem fills the fields of dst that are empty or "N/A" from src.
+func mergeItem(dst *Item, src Item) {
+    srcFields := stringFields(&src)
+    for i, field := range stringFields(dst) {
+        if naString(*field) == "" && naString(*srcFields[i]) != "" {
+            *field = *srcFields[i]
+        }
+    }
+    if naString(dst.TotalSeasons) == "" {
+        dst.TotalSeasons = src.TotalSeasons
+    }
+
+    sources := make(map[string]bool, len(dst.Ratings))
+    for _, r := range dst.Ratings {
+        sources[r.Source] = true
+    }
+    for _, r := range src.Ratings {
+        if !sources[r.Source] {
+            sources[r.Source] = true
+            dst.Ratings = append(dst.Ratings, r)
+        }
+    }
+}
+
+// complete reports whether every field of item is set, "N/A" meaning the field
+// is known to be absent. TotalSeasons is only set for series and not required.
+func complete(item Item) bool {
+    for _, field := range stringFields(&item) {
+        if *field == "" {
+            return false
+        }
+    }
+    return true
+}
+
+// StaticProvider serves a fixed list of items, e.g. as a local stand-in for the omdbapi.
+type StaticProvider struct {
+    items []Item
+    ids   map[string]int
+}
+
+// NewStaticProvider returns a provider serving items. Later items replace earlier
+// ones with the same imdbID, items without an imdbID are only found by Title.
+func NewStaticProvider(items ...Item) StaticProvider {
+    p := StaticProvider{ids: make(map[string]int, len(items))}
+    for _, item := range items {
+        if item.ImdbID == "" {
+            p.items = append(p.items, item)
+            continue
+        }
+        if i, ok := p.ids[item.ImdbID]; ok {
+            p.items[i] = item
+            continue
+        }
+        p.ids[item.ImdbID] = len(p.items)
+        p.items = append(p.items, item)
+    }
+    return p
+}
+
+// Info returns the item with the given imdbID.
+func (p StaticProvider) Info(ctx context.Context, id string, opts ...QueryOption) (Item, error) {
+    i, ok := p.ids[id]
+    if !ok {
+        return Item{}, fmt.Errorf("%w: %s", ErrNotFound, id)
+    }
+    return p.items[i], nil
+}
+
+// Title returns the first item with the given title, ignoring case. WithYear and WithType narrow the lookup.
+func (p StaticProvider) Title(ctx context.Context, title string, opts ...QueryOption) (Item, error) {
+    values := query(url.Values{}, opts)
+    for _, item := range p.items {
+        if !strings.EqualFold(item.Title, title) {
+            continue
+        }
+        if year := values.Get("y"); year != "" && !strings.HasPrefix(item.Year, year) {
+            continue
+        }
+        if titleType := values.Get("type"); titleType != "" && item.Type != titleType {
+            continue
+        }
+        return item, nil
+    }
+    return Item{}, fmt.Errorf("%w: %s", ErrNotFound, title)
+}
@@ -0,0 +1,178 @@
+package omdb_test
+
+import (
+    "context"
+    "errors"
+    "net/http"
+    "net/http/httptest"
+    "sync/atomic"
+    "testing"
+
+    "github.com/stretchr/testify/require"
+
+    "Azarc/omdb"
+)
+
+func TestCompositeProvider_Info(t *testing.T) {
+    ctx := context.Background()
+
+    var requests int32
+    testServer := httptest.NewServer(
+        http.HandlerFunc(
+            func(writer http.ResponseWriter, req *http.Request) {
+                atomic.AddInt32(&requests, 1)
+                if req.URL.Query().Get("i") != "tt0110475" {
+                    _, _ = writer.Write([]byte(`{"Response":"False","Error":"Incorrect IMDb ID."}`))
+                    return
+                }
+                _, _ = writer.Write([]byte(`{"Title":"The Mask","Year":"1994","Plot":"N/A","Metascore":"56","imdbID":"tt0110475","Type":"movie","Ratings":[{"Source":"Internet Movie Database","Value":"6.9/10"},{"Source":"Metacritic","Value":"56/100"}],"Response":"True"}`))
+            },
+        ),
+    )
+    defer testServer.Close()
+
+    local := omdb.NewStaticProvider(
+        omdb.Item{
+            Title:   "The Mask",
+            Plot:    "Bank clerk Stanley Ipkiss is transformed into a manic superhero.",
+            ImdbID:  "tt0110475",
+            Ratings: []omdb.Rating{{Source: "Internet Movie Database", Value: "7.0/10"}},
+        },
+        omdb.Item{Title: "Blacksmith Scene", Year: "1893", ImdbID: "tt0000005"},
+    )
+    provider := omdb.NewCompositeProvider(local, omdb.NewWithURL(testServer.URL, "apiKey"))
+
+    item, err := provider.Info(ctx, "tt0110475")
+    require.NoError(t, err)
+    require.Equal(t, "The Mask", item.Title)
+    require.Equal(t, "Bank clerk Stanley Ipkiss is transformed into a manic superhero.", item.Plot)
+    require.Equal(t, "1994", item.Year)
+    require.Equal(t, "56", item.Metascore)
+    require.Equal(t, []omdb.Rating{
+        {Source: "Internet Movie Database", Value: "7.0/10"},
+        {Source: "Metacritic", Value: "56/100"},
+    }, item.Ratings)
+
+    // only the local provider is asked for a title it knows in full
+    complete := omdb.NewStaticProvider(omdb.Item{
+        Title: "The Mask", Year: "1994", Rated: "N/A", Released: "N/A", Runtime: "N/A", Genre: "N/A",
+        Director: "N/A", Writer: "N/A", Actors: "N/A", Plot: "N/A", Language: "N/A", Country: "N/A",
+        Awards: "N/A", Poster: "N/A", Metascore: "N/A", ImdbRating: "N/A", ImdbVotes: "N/A",
+        ImdbID: "tt0110475", Type: "movie", DVD: "N/A", BoxOffice: "N/A", Production: "N/A", Website: "N/A",
+    })
+    requests = 0
+    _, err = omdb.NewCompositeProvider(complete, omdb.NewWithURL(testServer.URL, "apiKey")).Info(ctx, "tt0110475")
+    require.NoError(t, err)
+    require.Equal(t, int32(0), atomic.LoadInt32(&requests))
+
+    item, err = provider.Info(ctx, "tt0000005")
+    require.NoError(t, err)
+    require.Equal(t, "Blacksmith Scene", item.Title)
+
+    _, err = provider.Info(ctx, "tt0000000")
+    require.True(t, errors.Is(err, omdb.ErrNotFound))
+
+    _, err = omdb.NewCompositeProvider().Info(ctx, "tt0110475")
+    require.True(t, errors.Is(err, omdb.ErrNotFound))
+}
+
+func TestCompositeProvider_Errors(t *testing.T) {
+    ctx := context.Background()
+
+    testServer := httptest.NewServer(
+        http.HandlerFunc(
+            func(writer http.ResponseWriter, req *http.Request) {
+                writer.WriteHeader(http.StatusServiceUnavailable)
+            },
+        ),
+    )
+    defer testServer.Close()
+
+    local := omdb.NewStaticProvider(omdb.Item{Title: "The Mask", ImdbID: "tt0110475"})
+    client := omdb.NewWithURL(testServer.URL, "apiKey", omdb.WithRetry(omdb.RetryPolicy{MaxAttempts: 1}))
+    provider := omdb.NewCompositeProvider(local, client)
+
+    // a failing provider does not hide the results of the others
+    item, err := provider.Info(ctx, "tt0110475")
+    require.NoError(t, err)
+    require.Equal(t, "The Mask", item.Title)
+
+    // failures other than not found are reported over not found
+    _, err = provider.Info(ctx, "tt0000005")
+    require.True(t, errors.Is(err, omdb.ErrServer))
+}
+
+func TestCompositeProvider_Title(t *testing.T) {
+    ctx := context.Background()
+
+    first := omdb.NewStaticProvider(omdb.Item{Title: "The Mask", Year: "1994", ImdbID: "tt0110475"})
+    other := omdb.NewStaticProvider(omdb.Item{Title: "The Mask", Plot: "A 1961 horror film.", Year: "1961", ImdbID: "tt0055151"})
+    unknown := omdb.NewStaticProvider(omdb.Item{Title: "The Mask", Plot: "Unknown plot.", Country: "Canada"})
+    same := omdb.NewStaticProvider(omdb.Item{Title: "The Mask", Plot: "Bank clerk Stanley Ipkiss is transformed into a manic superhero.", ImdbID: "tt0110475"})
+
+    // results of other titles, or without an imdbID, are not merged
+    item, err := omdb.NewCompositeProvider(first, other, unknown, same).Title(ctx, "The Mask")
+    require.NoError(t, err)
+    require.Equal(t, "1994", item.Year)
+    require.Equal(t, "Bank clerk Stanley Ipkiss is transformed into a manic superhero.", item.Plot)
+    require.Empty(t, item.Country)
+
+    // nothing is merged into a first match without an imdbID
+    item, err = omdb.NewCompositeProvider(unknown, same).Title(ctx, "The Mask")
+    require.NoError(t, err)
+    require.Equal(t, "Unknown plot.", item.Plot)
+    require.Empty(t, item.ImdbID)
+}
+
+func TestStaticProvider_Title(t *testing.T) {
+    ctx := context.Background()
+    provider := omdb.NewStaticProvider(
+        omdb.Item{Title: "The Mask", Year: "1994", Type: "movie", ImdbID: "tt0110475"},
+        omdb.Item{Title: "The Mask", Year: "1961", Type: "movie", ImdbID: "tt0055151"},
+        omdb.Item{Title: "The Mask", Year: "1995–1997", Type: "series", ImdbID: "tt0112084"},
+        omdb.Item{Title: "Untitled", Year: "2001"},
+        omdb.Item{Title: "Unreleased", Year: "2030"},
+    )
+
+    item, err := provider.Title(ctx, "the mask")
+    require.NoError(t, err)
+    require.Equal(t, "tt0110475", item.ImdbID)
+
+    item, err = provider.Title(ctx, "The Mask", omdb.WithYear(1961))
+    require.NoError(t, err)
+    require.Equal(t, "tt0055151", item.ImdbID)
+
+    item, err = provider.Title(ctx, "The Mask", omdb.WithType("series"))
+    require.NoError(t, err)
+    require.Equal(t, "tt0112084", item.ImdbID)
+
+    _, err = provider.Title(ctx, "The Mask", omdb.WithYear(2020))
+    require.True(t, errors.Is(err, omdb.ErrNotFound))
+
+    // items without an imdbID do not replace each other
+    item, err = provider.Title(ctx, "Untitled")
+    require.NoError(t, err)
+    require.Equal(t, "2001", item.Year)
+    item, err = provider.Title(ctx, "Unreleased")
+    require.NoError(t, err)
+    require.Equal(t, "2030", item.Year)
+    _, err = provider.Info(ctx, "")
+    require.True(t, errors.Is(err, omdb.ErrNotFound))
+}
+
+func TestStreamInfo(t *testing.T) {
+    provider := omdb.NewStaticProvider(
+        omdb.Item{Title: "Blacksmith Scene", ImdbID: "tt0000005"},
+        omdb.Item{Title: "The Mask", ImdbID: "tt0110475"},
+    )
+    ids := []string{"tt0110475", "tt0000000", "tt0000005"}
+
+    var results []omdb.Result
+    for result := range omdb.StreamInfo(context.Background(), provider, ids, 2) {
+        results = append(results, result)
+    }
+    require.Len(t, results, 3)
+    require.Equal(t, "The Mask", results[0].Item.Title)
+    require.True(t, errors.Is(results[1].Err, omdb.ErrNotFound))
+    require.Equal(t, "Blacksmith Scene", results[2].Item.Title)
+}
@@ -0,0 +1,53 @@
+package omdb
+
+import (
+    "net/url"
+    "strconv"
+)
+
+// QueryOption sets an optional parameter on a request to the omdbapi.
+type QueryOption func(values url.Values)
+
+// Plot selects the length of the plot returned by the omdbapi.
+type Plot string
+
+const (
+    PlotShort Plot = "short"
+    PlotFull  Plot = "full"
+)
+
+// WithPlot requests the short (default) or full plot.
+func WithPlot(plot Plot) QueryOption {
+    return func(values url.Values) {
+        values.Set("plot", string(plot))
+    }
+}
+
+// query builds the parameters of a request from its required values and options.
+func query(values url.Values, opts []QueryOption) url.Values {
+    for _, opt := range opts {
+        opt(values)
+    }
+    return values
+}
+
+// WithType restricts a request to `movie`, `series` or `episode`.
+func WithType(titleType string) QueryOption {
+    return func(values url.Values) {
+        values.Set("type", titleType)
+    }
+}
+
+// WithYear restricts a request to titles released in year.
+func WithYear(year int) QueryOption {
+    return func(values url.Values) {
+        values.Set("y", strconv.Itoa(year))
+    }
+}
+
+// WithPage selects the page of search results to start at, pages are numbered from 1.
+func WithPage(page int) QueryOption {
+    return func(values url.Values) {
+        values.Set("page", strconv.Itoa(page))
+    }
+}
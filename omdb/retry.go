@@ -0,0 +1,128 @@
+package omdb
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "math"
+    "math/rand"
+    "net/http"
+    "net/url"
+    "strconv"
+    "time"
+)
+
+// RetryPolicy configures how failed requests are retried.
+type RetryPolicy struct {
+    // MaxAttempts is the total number of attempts, so 1 or less disables retries.
+    MaxAttempts int
+    // BaseDelay is the delay after the first attempt, doubling after every further attempt.
+    BaseDelay time.Duration
+    // MaxDelay caps the delay between attempts, 0 meaning no cap.
+    MaxDelay time.Duration
+    // Jitter shortens every delay by a random fraction of up to Jitter, between 0 and 1,
+    // so that concurrent clients do not retry in lockstep.
+    Jitter float64
+}
+
+// DefaultRetryPolicy retries up to three times, starting at half a second.
+var DefaultRetryPolicy = RetryPolicy{
+    MaxAttempts: 4,
+    BaseDelay:   500 * time.Millisecond,
+    MaxDelay:    10 * time.Second,
+    Jitter:      0.5,
+}
+
+// WithRetry retries transient failures according to p: network errors on
+// idempotent requests, server errors and requests to slow down. Retries stop
+// early with the last error when the next attempt would start after the
+// deadline of the context. Once the context is done the error of the context
+// is returned instead.
+func WithRetry(p RetryPolicy) Option {
+    return func(c *Client) {
+        c.retry = p
+    }
+}
+
+// fetch sends the request, retrying transient failures according to the retry policy.
+func (c Client) fetch(ctx context.Context, values url.Values) ([]byte, error) {
+    for attempt := 1; ; attempt++ {
+        body, retryAfter, err := c.do(ctx, values)
+        if keyError(err) && c.keys.Available() > 0 {
+            // the key was retired, try again right away with the next one
+            attempt--
+            continue
+        }
+        if err == nil || attempt >= c.retry.MaxAttempts || !retryable(ctx, err) {
+            return body, err
+        }
+
+        delay := c.retry.delay(attempt)
+        if retryAfter > delay {
+            delay = retryAfter
+        }
+        if deadline, ok := ctx.Deadline(); ok && time.Now().Add(delay).After(deadline) {
+            // ctx is still live, the lookup fails with the last error rather than the deadline
+            return nil, fmt.Errorf("%w (not retried, the next attempt would start after the deadline)", err)
+        }
+
+        timer := time.NewTimer(delay)
+        select {
+        case <-timer.C:
+        case <-ctx.Done():
+            timer.Stop()
+            return nil, fmt.Errorf("%w (last error: %v)", ctx.Err(), err)
+        }
+    }
+}
+
+// delay returns the backoff after the given attempt.
+func (p RetryPolicy) delay(attempt int) time.Duration {
+    delay := float64(p.BaseDelay) * math.Pow(2, float64(attempt-1))
+    if p.MaxDelay > 0 && delay > float64(p.MaxDelay) {
+        delay = float64(p.MaxDelay)
+    }
+    delay -= delay * p.Jitter * rand.Float64()
+    return time.Duration(delay)
+}
+
+// retryable reports whether err is a transient failure worth another attempt.
+func retryable(ctx context.Context, err error) bool {
+    if ctx.Err() != nil {
+        return false
+    }
+    if errors.Is(err, ErrServer) || errors.Is(err, ErrTooManyRequests) {
+        return true
+    }
+    var transportErr transportError
+    if errors.As(err, &transportErr) {
+        return idempotent(transportErr.method)
+    }
+    return false
+}
+
+// idempotent reports whether a request with the given method may be sent again
+// after it possibly reached the server.
+func idempotent(method string) bool {
+    switch method {
+    case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+        return true
+    }
+    return false
+}
+
+// parseRetryAfter parses a Retry-After header given either in seconds or as a date.
+func parseRetryAfter(header string) time.Duration {
+    if header == "" {
+        return 0
+    }
+    seconds, err := strconv.Atoi(header)
+    if err == nil {
+        return time.Duration(seconds) * time.Second
+    }
+    date, err := http.ParseTime(header)
+    if err != nil {
+        return 0
+    }
+    return time.Until(date)
+}
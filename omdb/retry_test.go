@@ -0,0 +1,161 @@
+package omdb_test
+
+import (
+    "context"
+    "errors"
+    "net/http"
+    "net/http/httptest"
+    "sync/atomic"
+    "testing"
+    "time"
+
+    "github.com/stretchr/testify/require"
+
+    "Azarc/omdb"
+)
+
+// failure injects a failed response for a single request.
+type failure func(writer http.ResponseWriter)
+
+func serverError(writer http.ResponseWriter) {
+    writer.WriteHeader(http.StatusServiceUnavailable)
+}
+
+func dropConnection(writer http.ResponseWriter) {
+    conn, _, err := writer.(http.Hijacker).Hijack()
+    if err == nil {
+        conn.Close()
+    }
+}
+
+func tooManyRequests(writer http.ResponseWriter) {
+    writer.Header().Set("Retry-After", "1")
+    writer.WriteHeader(http.StatusTooManyRequests)
+}
+
+func notFound(writer http.ResponseWriter) {
+    _, _ = writer.Write([]byte(`{"Response":"False","Error":"Incorrect IMDb ID."}`))
+}
+
+func limitReached(writer http.ResponseWriter) {
+    writer.WriteHeader(http.StatusUnauthorized)
+    _, _ = writer.Write([]byte(`{"Response":"False","Error":"Request limit reached!"}`))
+}
+
+// newFailingServer fails the first requests with failures and answers successfully afterwards.
+func newFailingServer(requests *int32, failures ...failure) *httptest.Server {
+    return httptest.NewServer(
+        http.HandlerFunc(
+            func(writer http.ResponseWriter, req *http.Request) {
+                i := int(atomic.AddInt32(requests, 1)) - 1
+                if i < len(failures) {
+                    failures[i](writer)
+                    return
+                }
+                _, _ = writer.Write([]byte(`{"Title":"Blacksmith Scene","imdbID":"tt0000005","Response":"True"}`))
+            },
+        ),
+    )
+}
+
+func TestInfo_Retry(t *testing.T) {
+    policy := omdb.RetryPolicy{
+        MaxAttempts: 3,
+        BaseDelay:   time.Millisecond,
+        MaxDelay:    5 * time.Millisecond,
+        Jitter:      0.5,
+    }
+
+    testTable := []struct {
+        name             string
+        failures         []failure
+        expectedErr      error
+        expectedRequests int32
+    }{
+        {
+            name:             "no failure",
+            expectedRequests: 1,
+        },
+        {
+            name:             "server errors",
+            failures:         []failure{serverError, serverError},
+            expectedRequests: 3,
+        },
+        {
+            name:             "dropped connection",
+            failures:         []failure{dropConnection},
+            expectedRequests: 2,
+        },
+        {
+            name:             "attempts exhausted",
+            failures:         []failure{serverError, serverError, serverError},
+            expectedErr:      omdb.ErrServer,
+            expectedRequests: 3,
+        },
+        {
+            name:             "not found",
+            failures:         []failure{notFound},
+            expectedErr:      omdb.ErrNotFound,
+            expectedRequests: 1,
+        },
+        {
+            name:             "limit reached",
+            failures:         []failure{limitReached},
+            expectedErr:      omdb.ErrRequestLimitReached,
+            expectedRequests: 1,
+        },
+    }
+
+    for _, test := range testTable {
+        t.Run(test.name, func(t *testing.T) {
+            var requests int32
+            testServer := newFailingServer(&requests, test.failures...)
+            defer testServer.Close()
+
+            client := omdb.NewWithURL(testServer.URL, "apiKey", omdb.WithRetry(policy))
+
+            item, err := client.Info(context.Background(), "tt0000005")
+            if test.expectedErr != nil {
+                require.True(t, errors.Is(err, test.expectedErr), err)
+            } else {
+                require.NoError(t, err)
+                require.Equal(t, "tt0000005", item.ImdbID)
+            }
+            require.Equal(t, test.expectedRequests, atomic.LoadInt32(&requests))
+        })
+    }
+}
+
+func TestInfo_RetryAfter(t *testing.T) {
+    policy := omdb.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond}
+
+    t.Run("waits", func(t *testing.T) {
+        var requests int32
+        testServer := newFailingServer(&requests, tooManyRequests)
+        defer testServer.Close()
+
+        client := omdb.NewWithURL(testServer.URL, "apiKey", omdb.WithRetry(policy))
+
+        ctx, cancel := context.WithCancel(context.Background())
+        time.AfterFunc(100*time.Millisecond, cancel)
+        _, err := client.Info(ctx, "tt0000005")
+        require.True(t, errors.Is(err, context.Canceled), err)
+        require.Contains(t, err.Error(), "too many requests")
+        require.Equal(t, int32(1), atomic.LoadInt32(&requests))
+    })
+
+    t.Run("respects deadline", func(t *testing.T) {
+        var requests int32
+        testServer := newFailingServer(&requests, tooManyRequests)
+        defer testServer.Close()
+
+        client := omdb.NewWithURL(testServer.URL, "apiKey", omdb.WithRetry(policy))
+
+        ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+        defer cancel()
+        _, err := client.Info(ctx, "tt0000005")
+        require.True(t, errors.Is(err, omdb.ErrTooManyRequests), err)
+        require.False(t, errors.Is(err, context.DeadlineExceeded), err)
+        require.Equal(t, int32(1), atomic.LoadInt32(&requests))
+    })
+}
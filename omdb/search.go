@@ -0,0 +1,146 @@
+package omdb
+
+import (
+    "context"
+    "errors"
+    "net/url"
+    "strconv"
+)
+
+// SearchResult is a title matching a search, see Client.Info for its details.
+type SearchResult struct {
+    ImdbID string `json:"imdbID"`
+    Title  string `json:"Title"`
+    Year   string `json:"Year"`
+    Type   string `json:"Type"`
+    Poster string `json:"Poster"`
+}
+
+// searchPageSize is the number of results on a full page.
+const searchPageSize = 10
+
+// searchPage is a single page of search results.
+type searchPage struct {
+    Search       []SearchResult `json:"Search"`
+    TotalResults string         `json:"totalResults"`
+}
+
+// SearchIterator walks the pages of a search, requesting the next page once
+// the results of the current one are used up.
+type SearchIterator struct {
+    ctx      context.Context
+    client   Client
+    values   url.Values
+    page     int
+    limit    int
+    maxPages int
+
+    // pages counts the pages requested so far, page being the next one to request.
+    pages   int
+    total   int
+    seen    int
+    results []SearchResult
+    current SearchResult
+    done    bool
+    err     error
+}
+
+// Search returns an iterator over the titles matching title. Use WithType,
+// WithYear and WithPage to narrow the search.
+func (c Client) Search(ctx context.Context, title string, opts ...QueryOption) *SearchIterator {
+    values := query(url.Values{
+        "s": []string{title},
+    }, opts)
+
+    page, err := strconv.Atoi(values.Get("page"))
+    if err != nil {
+        page = 1
+    }
+    return &SearchIterator{
+        ctx:    ctx,
+        client: c,
+        values: values,
+        page:   page,
+    }
+}
+
+// Limit stops the iterator after n results, 0 meaning no limit.
+func (it *SearchIterator) Limit(n int) *SearchIterator {
+    it.limit = n
+    return it
+}
+
+// MaxPages stops the iterator after requesting n pages, 0 meaning no limit.
+// Every page costs one request against the daily quota.
+func (it *SearchIterator) MaxPages(n int) *SearchIterator {
+    it.maxPages = n
+    return it
+}
+
+// Next advances to the next result, requesting the next page when needed.
+// It returns false when the search is exhausted, a limit is hit or an error occurred.
+func (it *SearchIterator) Next() bool {
+    if it.done || (it.limit > 0 && it.seen >= it.limit) {
+        return false
+    }
+
+    if len(it.results) == 0 {
+        if it.pages > 0 && (it.page-1)*searchPageSize >= it.total {
+            return false
+        }
+        if it.maxPages > 0 && it.pages >= it.maxPages {
+            return false
+        }
+        it.err = it.fetch()
+        if it.err != nil || len(it.results) == 0 {
+            it.done = true
+            return false
+        }
+    }
+
+    it.current = it.results[0]
+    it.results = it.results[1:]
+    it.seen++
+    return true
+}
+
+func (it *SearchIterator) fetch() error {
+    values := url.Values{}
+    for key, value := range it.values {
+        values[key] = value
+    }
+    values.Set("page", strconv.Itoa(it.page))
+
+    var page searchPage
+    err := it.client.get(it.ctx, values, &page)
+    if errors.Is(err, ErrNotFound) {
+        return nil
+    }
+    if err != nil {
+        return err
+    }
+
+    it.total, err = strconv.Atoi(page.TotalResults)
+    if err != nil {
+        return err
+    }
+    it.results = page.Search
+    it.pages++
+    it.page++
+    return nil
+}
+
+// Result returns the current result.
+func (it *SearchIterator) Result() SearchResult {
+    return it.current
+}
+
+// Total returns the totalResults reported by the omdbapi, once the first page was requested.
+func (it *SearchIterator) Total() int {
+    return it.total
+}
+
+// Err returns the error that stopped the iterator, if any.
+func (it *SearchIterator) Err() error {
+    return it.err
+}
@@ -0,0 +1,145 @@
+package omdb_test
+
+import (
+    "context"
+    "fmt"
+    "net/http"
+    "net/http/httptest"
+    "strconv"
+    "strings"
+    "testing"
+
+    "github.com/stretchr/testify/require"
+
+    "Azarc/omdb"
+)
+
+// newSearchServer serves total search results for "mask", ten per page.
+func newSearchServer(t *testing.T, total int, requests *[]string) *httptest.Server {
+    return httptest.NewServer(
+        http.HandlerFunc(
+            func(writer http.ResponseWriter, req *http.Request) {
+                query := req.URL.Query()
+                *requests = append(*requests, query.Get("page"))
+                require.Equal(t, "mask", query.Get("s"))
+                require.Equal(t, "movie", query.Get("type"))
+
+                page, err := strconv.Atoi(query.Get("page"))
+                require.NoError(t, err)
+
+                var results []string
+                for i := (page-1)*10 + 1; i <= page*10 && i <= total; i++ {
+                    results = append(results, fmt.Sprintf(`{"Title":"The Mask %d","Year":"1994","imdbID":"tt%07d","Type":"movie","Poster":"N/A"}`, i, i))
+                }
+                if len(results) == 0 {
+                    _, err = writer.Write([]byte(`{"Response":"False","Error":"Movie not found!"}`))
+                    require.NoError(t, err)
+                    return
+                }
+                _, err = fmt.Fprintf(writer, `{"Search":[%s],"totalResults":"%d","Response":"True"}`, strings.Join(results, ","), total)
+                require.NoError(t, err)
+            },
+        ),
+    )
+}
+
+func TestSearch(t *testing.T) {
+    ctx := context.Background()
+
+    testTable := []struct {
+        name             string
+        total            int
+        opts             []omdb.QueryOption
+        limit            int
+        maxPages         int
+        expectedResults  int
+        expectedFirst    string
+        expectedRequests []string
+    }{
+        {
+            name:             "no results",
+            total:            0,
+            expectedRequests: []string{"1"},
+        },
+        {
+            name:             "all pages",
+            total:            23,
+            expectedResults:  23,
+            expectedFirst:    "tt0000001",
+            expectedRequests: []string{"1", "2", "3"},
+        },
+        {
+            name:             "full last page",
+            total:            20,
+            expectedResults:  20,
+            expectedFirst:    "tt0000001",
+            expectedRequests: []string{"1", "2"},
+        },
+        {
+            name:             "limit",
+            total:            23,
+            limit:            12,
+            expectedResults:  12,
+            expectedFirst:    "tt0000001",
+            expectedRequests: []string{"1", "2"},
+        },
+        {
+            name:             "max pages",
+            total:            23,
+            maxPages:         1,
+            expectedResults:  10,
+            expectedFirst:    "tt0000001",
+            expectedRequests: []string{"1"},
+        },
+        {
+            name:             "start page",
+            total:            23,
+            opts:             []omdb.QueryOption{omdb.WithPage(2)},
+            expectedResults:  13,
+            expectedFirst:    "tt0000011",
+            expectedRequests: []string{"2", "3"},
+        },
+    }
+
+    for _, test := range testTable {
+        t.Run(test.name, func(t *testing.T) {
+            var requests []string
+            testServer := newSearchServer(t, test.total, &requests)
+            defer testServer.Close()
+
+            client := omdb.NewWithURL(testServer.URL, "apiKey")
+            opts := append([]omdb.QueryOption{omdb.WithType("movie")}, test.opts...)
+            it := client.Search(ctx, "mask", opts...).Limit(test.limit).MaxPages(test.maxPages)
+
+            var results []omdb.SearchResult
+            for it.Next() {
+                results = append(results, it.Result())
+            }
+            require.NoError(t, it.Err())
+            require.Len(t, results, test.expectedResults)
+            if test.expectedFirst != "" {
+                require.Equal(t, test.expectedFirst, results[0].ImdbID)
+                require.Equal(t, test.total, it.Total())
+            }
+            require.Equal(t, test.expectedRequests, requests)
+        })
+    }
+}
+
+func TestSearch_Error(t *testing.T) {
+    testServer := httptest.NewServer(
+        http.HandlerFunc(
+            func(writer http.ResponseWriter, req *http.Request) {
+                _, err := writer.Write([]byte(`{"Response":"False","Error":"Too many results."}`))
+                require.NoError(t, err)
+            },
+        ),
+    )
+    defer testServer.Close()
+
+    client := omdb.NewWithURL(testServer.URL, "apiKey")
+    it := client.Search(context.Background(), "a")
+    require.False(t, it.Next())
+    require.EqualError(t, it.Err(), "Too many results.")
+    require.False(t, it.Next())
+}
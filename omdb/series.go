@@ -0,0 +1,165 @@
+package omdb
+
+import (
+    "context"
+    "net/url"
+    "strconv"
+    "time"
+)
+
+// seasonDateLayout is the layout of the Released field of a SeasonEpisode, e.g. `1994-09-22`.
+const seasonDateLayout = "2006-01-02"
+
+// Season is the episode listing of a single season of a series.
+type Season struct {
+    Title        string          `json:"Title"`
+    Season       string          `json:"Season"`
+    TotalSeasons string          `json:"totalSeasons"`
+    Episodes     []SeasonEpisode `json:"Episodes"`
+}
+
+// SeasonEpisode is the summary of an episode in a Season, see Client.Episode for its details.
+type SeasonEpisode struct {
+    Title      string `json:"Title"`
+    Released   string `json:"Released"`
+    Episode    string `json:"Episode"`
+    ImdbRating string `json:"imdbRating"`
+    ImdbID     string `json:"imdbID"`
+}
+
+// Episode is the full record of an episode together with its place in the series.
+type Episode struct {
+    Item
+    Season   string `json:"Season"`
+    Episode  string `json:"Episode"`
+    SeriesID string `json:"seriesID"`
+}
+
+// TypedSeason holds the parsed values of a Season.
+type TypedSeason struct {
+    Title        string
+    Season       int
+    TotalSeasons int
+    Episodes     []TypedSeasonEpisode
+}
+
+// TypedSeasonEpisode holds the parsed values of a SeasonEpisode. Fields the
+// omdbapi reports as "N/A" are left at their zero value.
+type TypedSeasonEpisode struct {
+    Title      string
+    Released   time.Time
+    Episode    int
+    ImdbRating float64
+    ImdbID     string
+}
+
+// TypedEpisode holds the parsed values of an Episode.
+type TypedEpisode struct {
+    TypedItem
+    Season   int
+    Episode  int
+    SeriesID string
+}
+
+// Typed parses the raw string fields of the Season and its episodes.
+func (s Season) Typed() (TypedSeason, error) {
+    typed := TypedSeason{Title: naString(s.Title)}
+
+    season, err := parseNumber(s.Season)
+    if err != nil {
+        return TypedSeason{}, err
+    }
+    typed.Season = int(season)
+    totalSeasons, err := parseNumber(s.TotalSeasons)
+    if err != nil {
+        return TypedSeason{}, err
+    }
+    typed.TotalSeasons = int(totalSeasons)
+
+    for _, e := range s.Episodes {
+        episode, err := e.Typed()
+        if err != nil {
+            return TypedSeason{}, err
+        }
+        typed.Episodes = append(typed.Episodes, episode)
+    }
+    return typed, nil
+}
+
+// Typed parses the raw string fields of the SeasonEpisode.
+func (e SeasonEpisode) Typed() (TypedSeasonEpisode, error) {
+    typed := TypedSeasonEpisode{
+        Title:  naString(e.Title),
+        ImdbID: naString(e.ImdbID),
+    }
+
+    var err error
+    if naString(e.Released) != "" {
+        typed.Released, err = time.Parse(seasonDateLayout, e.Released)
+        if err != nil {
+            return TypedSeasonEpisode{}, err
+        }
+    }
+    episode, err := parseNumber(e.Episode)
+    if err != nil {
+        return TypedSeasonEpisode{}, err
+    }
+    typed.Episode = int(episode)
+    typed.ImdbRating, err = parseRating(e.ImdbRating)
+    if err != nil {
+        return TypedSeasonEpisode{}, err
+    }
+    return typed, nil
+}
+
+// Typed parses the raw string fields of the Episode.
+func (e Episode) Typed() (TypedEpisode, error) {
+    item, err := e.Item.Typed()
+    if err != nil {
+        return TypedEpisode{}, err
+    }
+    typed := TypedEpisode{TypedItem: item, SeriesID: naString(e.SeriesID)}
+
+    season, err := parseNumber(e.Season)
+    if err != nil {
+        return TypedEpisode{}, err
+    }
+    typed.Season = int(season)
+    episode, err := parseNumber(e.Episode)
+    if err != nil {
+        return TypedEpisode{}, err
+    }
+    typed.Episode = int(episode)
+    return typed, nil
+}
+
+// Season lists the episodes of season of the series with the given imdbID.
+func (c Client) Season(ctx context.Context, seriesID string, season int, opts ...QueryOption) (Season, error) {
+    values := query(url.Values{
+        "i":      []string{seriesID},
+        "Season": []string{strconv.Itoa(season)},
+    }, opts)
+
+    var s Season
+    err := c.get(ctx, values, &s)
+    if err != nil {
+        return Season{}, err
+    }
+    return s, nil
+}
+
+// Episode looks up episode of season of the series with the given imdbID.
+func (c Client) Episode(ctx context.Context, seriesID string, season int, episode int, opts ...QueryOption) (Episode, error) {
+    values := query(url.Values{
+        "i":       []string{seriesID},
+        "Season":  []string{strconv.Itoa(season)},
+        "Episode": []string{strconv.Itoa(episode)},
+    }, opts)
+
+    var e Episode
+    err := c.get(ctx, values, &e)
+    if err != nil {
+        return Episode{}, err
+    }
+    return e, nil
+}
@@ -0,0 +1,173 @@
+package omdb_test
+
+import (
+    "context"
+    "net/http"
+    "net/http/httptest"
+    "net/url"
+    "testing"
+    "time"
+
+    "github.com/stretchr/testify/require"
+
+    "Azarc/omdb"
+)
+
+func TestSeason(t *testing.T) {
+    testServer := httptest.NewServer(
+        http.HandlerFunc(
+            func(writer http.ResponseWriter, req *http.Request) {
+                require.Equal(t, url.Values{
+                    "apikey": []string{"apiKey"},
+                    "i":      []string{"tt0108778"},
+                    "Season": []string{"1"},
+                }, req.URL.Query())
+                _, err := writer.Write([]byte(`{"Title":"Friends","Season":"1","totalSeasons":"10","Episodes":[{"Title":"The One Where Monica Gets a Roommate","Released":"1994-09-22","Episode":"1","imdbRating":"8.3","imdbID":"tt0583459"},{"Title":"The One with the Sonogram at the End","Released":"1994-09-29","Episode":"2","imdbRating":"8.1","imdbID":"tt0583647"}],"Response":"True"}`))
+                require.NoError(t, err)
+            },
+        ),
+    )
+    defer testServer.Close()
+
+    client := omdb.NewWithURL(testServer.URL, "apiKey")
+
+    season, err := client.Season(context.Background(), "tt0108778", 1)
+    require.NoError(t, err)
+    require.Equal(t, omdb.Season{
+        Title:        "Friends",
+        Season:       "1",
+        TotalSeasons: "10",
+        Episodes: []omdb.SeasonEpisode{
+            {
+                Title:      "The One Where Monica Gets a Roommate",
+                Released:   "1994-09-22",
+                Episode:    "1",
+                ImdbRating: "8.3",
+                ImdbID:     "tt0583459",
+            },
+            {
+                Title:      "The One with the Sonogram at the End",
+                Released:   "1994-09-29",
+                Episode:    "2",
+                ImdbRating: "8.1",
+                ImdbID:     "tt0583647",
+            },
+        },
+    }, season)
+}
+
+func TestEpisode(t *testing.T) {
+    testServer := httptest.NewServer(
+        http.HandlerFunc(
+            func(writer http.ResponseWriter, req *http.Request) {
+                require.Equal(t, url.Values{
+                    "apikey":  []string{"apiKey"},
+                    "i":       []string{"tt0108778"},
+                    "Season":  []string{"1"},
+                    "Episode": []string{"2"},
+                }, req.URL.Query())
+                _, err := writer.Write([]byte(`{"Title":"The One with the Sonogram at the End","Year":"1994","Released":"29 Sep 1994","Season":"1","Episode":"2","Runtime":"22 min","imdbRating":"8.1","imdbID":"tt0583647","seriesID":"tt0108778","Type":"episode","Response":"True"}`))
+                require.NoError(t, err)
+            },
+        ),
+    )
+    defer testServer.Close()
+
+    client := omdb.NewWithURL(testServer.URL, "apiKey")
+
+    episode, err := client.Episode(context.Background(), "tt0108778", 1, 2)
+    require.NoError(t, err)
+    require.Equal(t, omdb.Episode{
+        Item: omdb.Item{
+            Title:      "The One with the Sonogram at the End",
+            Year:       "1994",
+            Released:   "29 Sep 1994",
+            Runtime:    "22 min",
+            ImdbRating: "8.1",
+            ImdbID:     "tt0583647",
+            Type:       "episode",
+            Response:   "True",
+        },
+        Season:   "1",
+        Episode:  "2",
+        SeriesID: "tt0108778",
+    }, episode)
+}
+
+func TestSeason_Typed(t *testing.T) {
+    season := omdb.Season{
+        Title:        "Friends",
+        Season:       "1",
+        TotalSeasons: "10",
+        Episodes: []omdb.SeasonEpisode{
+            {Title: "The One Where Monica Gets a Roommate", Released: "1994-09-22", Episode: "1", ImdbRating: "8.3", ImdbID: "tt0583459"},
+            {Title: "The One with the Sonogram at the End", Released: "N/A", Episode: "2", ImdbRating: "N/A", ImdbID: "tt0583647"},
+        },
+    }
+
+    typed, err := season.Typed()
+    require.NoError(t, err)
+    require.Equal(t, omdb.TypedSeason{
+        Title:        "Friends",
+        Season:       1,
+        TotalSeasons: 10,
+        Episodes: []omdb.TypedSeasonEpisode{
+            {
+                Title:      "The One Where Monica Gets a Roommate",
+                Released:   time.Date(1994, time.September, 22, 0, 0, 0, 0, time.UTC),
+                Episode:    1,
+                ImdbRating: 8.3,
+                ImdbID:     "tt0583459",
+            },
+            {
+                Title:   "The One with the Sonogram at the End",
+                Episode: 2,
+                ImdbID:  "tt0583647",
+            },
+        },
+    }, typed)
+
+    season.Episodes[0].Episode = "one"
+    _, err = season.Typed()
+    require.Error(t, err)
+}
+
+func TestEpisode_Typed(t *testing.T) {
+    episode := omdb.Episode{
+        Item: omdb.Item{
+            Title:    "The One with the Sonogram at the End",
+            Released: "29 Sep 1994",
+            Runtime:  "22 min",
+            ImdbID:   "tt0583647",
+        },
+        Season:   "1",
+        Episode:  "2",
+        SeriesID: "tt0108778",
+    }
+
+    typed, err := episode.Typed()
+    require.NoError(t, err)
+    require.Equal(t, "The One with the Sonogram at the End", typed.Title)
+    require.Equal(t, time.Date(1994, time.September, 29, 0, 0, 0, 0, time.UTC), typed.Released)
+    require.Equal(t, 22*time.Minute, typed.Runtime)
+    require.Equal(t, 1, typed.Season)
+    require.Equal(t, 2, typed.Episode)
+    require.Equal(t, "tt0108778", typed.SeriesID)
+}
+
+func TestSeason_NotFound(t *testing.T) {
+    testServer := httptest.NewServer(
+        http.HandlerFunc(
+            func(writer http.ResponseWriter, req *http.Request) {
+                _, err := writer.Write([]byte(`{"Response":"False","Error":"Series or season not found!"}`))
+                require.NoError(t, err)
+            },
+        ),
+    )
+    defer testServer.Close()
+
+    client := omdb.NewWithURL(testServer.URL, "apiKey")
+
+    _, err := client.Season(context.Background(), "tt0108778", 42)
+    require.EqualError(t, err, "not found: Series or season not found!")
+}
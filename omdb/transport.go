@@ -0,0 +1,101 @@
+package omdb
+
+import (
+    "net/http"
+    "time"
+)
+
+// Middleware wraps the RoundTripper sending the requests of a Client, e.g. to
+// log requests, record metrics or add authentication headers.
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// RoundTripperFunc adapts a function to an http.RoundTripper, for writing Middleware.
+type RoundTripperFunc func(req *http.Request) (*http.Response, error)
+
+// RoundTrip calls f(req).
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+    return f(req)
+}
+
+// WithHTTPClient sends requests with client instead of a default http.Client.
+// The client is copied, other options such as WithTimeout do not modify it.
+func WithHTTPClient(client *http.Client) Option {
+    return func(c *Client) {
+        if client != nil {
+            c.httpClient = client
+        }
+    }
+}
+
+// WithTimeout limits the time of a single request, including reading the body.
+// Retries each get a timeout of their own.
+func WithTimeout(timeout time.Duration) Option {
+    return func(c *Client) {
+        c.timeout = timeout
+    }
+}
+
+// WithUserAgent sets the User-Agent header of every request.
+func WithUserAgent(userAgent string) Option {
+    return func(c *Client) {
+        c.userAgent = userAgent
+    }
+}
+
+// WithTransport sends requests with transport, e.g. an *http.Transport configured
+// with a Proxy. It replaces the Transport of the http.Client.
+func WithTransport(transport http.RoundTripper) Option {
+    return func(c *Client) {
+        c.transport = transport
+    }
+}
+
+// WithBaseURL sends requests to url instead of https://www.omdbapi.com.
+func WithBaseURL(url string) Option {
+    return func(c *Client) {
+        c.url = url
+    }
+}
+
+// WithMiddleware wraps the transport in middleware. Multiple calls add to the
+// chain, the first middleware being the outermost and so seeing requests first.
+func WithMiddleware(middleware ...Middleware) Option {
+    return func(c *Client) {
+        c.middleware = append(c.middleware, middleware...)
+    }
+}
+
+// buildHTTPClient applies the transport options to a copy of the http.Client.
+func (c *Client) buildHTTPClient() {
+    client := *c.httpClient
+    if c.timeout > 0 {
+        client.Timeout = c.timeout
+    }
+
+    transport := client.Transport
+    if c.transport != nil {
+        transport = c.transport
+    }
+    if transport == nil {
+        transport = http.DefaultTransport
+    }
+    if c.userAgent != "" {
+        transport = userAgent(c.userAgent)(transport)
+    }
+    for i := len(c.middleware) - 1; i >= 0; i-- {
+        transport = c.middleware[i](transport)
+    }
+    client.Transport = transport
+    c.httpClient = &client
+}
+
+func userAgent(agent string) Middleware {
+    return func(next http.RoundTripper) http.RoundTripper {
+        return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+            // RoundTrippers must not modify the request
+            req = req.Clone(req.Context())
+            req.Header.Set("User-Agent", agent)
+            return next.RoundTrip(req)
+        })
+    }
+}
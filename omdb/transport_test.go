@@ -0,0 +1,100 @@
+package omdb_test
+
+import (
+    "context"
+    "net/http"
+    "net/http/httptest"
+    "testing"
+    "time"
+
+    "github.com/stretchr/testify/require"
+
+    "Azarc/omdb"
+)
+
+func TestClient_HTTPOptions(t *testing.T) {
+    ctx := context.Background()
+
+    testServer := httptest.NewServer(
+        http.HandlerFunc(
+            func(writer http.ResponseWriter, req *http.Request) {
+                require.Equal(t, "imdb-enricher/1.0", req.Header.Get("User-Agent"))
+                require.Equal(t, "outer,inner", req.Header.Get("X-Chain"))
+                _, err := writer.Write([]byte(`{"Title":"The Mask","imdbID":"tt0110475","Response":"True"}`))
+                require.NoError(t, err)
+            },
+        ),
+    )
+    defer testServer.Close()
+
+    var calls []string
+    chain := func(name string) omdb.Middleware {
+        return func(next http.RoundTripper) http.RoundTripper {
+            return omdb.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+                calls = append(calls, name)
+                req = req.Clone(req.Context())
+                if value := req.Header.Get("X-Chain"); value != "" {
+                    name = value + "," + name
+                }
+                req.Header.Set("X-Chain", name)
+                return next.RoundTrip(req)
+            })
+        }
+    }
+
+    httpClient := &http.Client{}
+    client := omdb.New("apiKey",
+        omdb.WithHTTPClient(httpClient),
+        omdb.WithBaseURL(testServer.URL),
+        omdb.WithUserAgent("imdb-enricher/1.0"),
+        omdb.WithTimeout(time.Second),
+        omdb.WithMiddleware(chain("outer")),
+        omdb.WithMiddleware(chain("inner")),
+    )
+
+    item, err := client.Info(ctx, "tt0110475")
+    require.NoError(t, err)
+    require.Equal(t, "The Mask", item.Title)
+    require.Equal(t, []string{"outer", "inner"}, calls)
+    require.Zero(t, httpClient.Timeout, "expected the given http.Client not to be modified")
+    require.Nil(t, httpClient.Transport, "expected the given http.Client not to be modified")
+}
+
+func TestClient_WithTimeout(t *testing.T) {
+    ctx := context.Background()
+
+    testServer := httptest.NewServer(
+        http.HandlerFunc(
+            func(writer http.ResponseWriter, req *http.Request) {
+                time.Sleep(200 * time.Millisecond)
+            },
+        ),
+    )
+    defer testServer.Close()
+
+    client := omdb.NewWithURL(testServer.URL, "apiKey",
+        omdb.WithTimeout(20*time.Millisecond),
+        omdb.WithRetry(omdb.RetryPolicy{MaxAttempts: 1}),
+    )
+    _, err := client.Info(ctx, "tt0110475")
+    require.Error(t, err)
+}
+
+func TestClient_WithTransport(t *testing.T) {
+    ctx := context.Background()
+
+    var sent bool
+    transport := omdb.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+        sent = true
+        recorder := httptest.NewRecorder()
+        _, err := recorder.WriteString(`{"Title":"The Mask","Response":"True"}`)
+        require.NoError(t, err)
+        return recorder.Result(), nil
+    })
+
+    client := omdb.New("apiKey", omdb.WithTransport(transport))
+    item, err := client.Info(ctx, "tt0110475")
+    require.NoError(t, err)
+    require.True(t, sent)
+    require.Equal(t, "The Mask", item.Title)
+}
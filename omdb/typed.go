@@ -0,0 +1,193 @@
+package omdb
+
+import (
+    "fmt"
+    "strconv"
+    "strings"
+    "time"
+)
+
+// notAvailable is the value used by the omdbapi for absent fields.
+const notAvailable = "N/A"
+
+// dateLayout is the layout of the Released and DVD fields, e.g. `09 May 1893`.
+const dateLayout = "02 Jan 2006"
+
+// TypedItem holds the parsed values of an Item. Fields the omdbapi reports as
+// "N/A" are left at their zero value.
+type TypedItem struct {
+    Title        string
+    StartYear    int
+    // EndYear is only set for series that have ended, e.g. `1994–2004`.
+    EndYear      int
+    Rated        string
+    Released     time.Time
+    Runtime      time.Duration
+    Genres       []string
+    Directors    []string
+    Writers      []string
+    Actors       []string
+    Plot         string
+    Languages    []string
+    Countries    []string
+    Awards       string
+    Poster       string
+    Ratings      []Rating
+    Metascore    int
+    ImdbRating   float64
+    ImdbVotes    int
+    ImdbID       string
+    Type         string
+    DVD          time.Time
+    // BoxOffice is the gross in whole dollars.
+    BoxOffice    int64
+    Production   string
+    Website      string
+    TotalSeasons int
+}
+
+// Typed parses the raw string fields of the Item.
+func (i Item) Typed() (TypedItem, error) {
+    typed := TypedItem{
+        Title:      naString(i.Title),
+        Rated:      naString(i.Rated),
+        Genres:     naList(i.Genre),
+        Directors:  naList(i.Director),
+        Writers:    naList(i.Writer),
+        Actors:     naList(i.Actors),
+        Plot:       naString(i.Plot),
+        Languages:  naList(i.Language),
+        Countries:  naList(i.Country),
+        Awards:     naString(i.Awards),
+        Poster:     naString(i.Poster),
+        Ratings:    i.Ratings,
+        ImdbID:     naString(i.ImdbID),
+        Type:       naString(i.Type),
+        Production: naString(i.Production),
+        Website:    naString(i.Website),
+    }
+
+    var err error
+    typed.StartYear, typed.EndYear, err = parseYears(i.Year)
+    if err != nil {
+        return TypedItem{}, err
+    }
+    typed.Released, err = parseDate(i.Released)
+    if err != nil {
+        return TypedItem{}, err
+    }
+    typed.DVD, err = parseDate(i.DVD)
+    if err != nil {
+        return TypedItem{}, err
+    }
+    typed.Runtime, err = parseRuntime(i.Runtime)
+    if err != nil {
+        return TypedItem{}, err
+    }
+    metascore, err := parseNumber(i.Metascore)
+    if err != nil {
+        return TypedItem{}, err
+    }
+    typed.Metascore = int(metascore)
+    typed.ImdbRating, err = parseRating(i.ImdbRating)
+    if err != nil {
+        return TypedItem{}, err
+    }
+    votes, err := parseNumber(i.ImdbVotes)
+    if err != nil {
+        return TypedItem{}, err
+    }
+    typed.ImdbVotes = int(votes)
+    typed.BoxOffice, err = parseNumber(strings.TrimPrefix(i.BoxOffice, "$"))
+    if err != nil {
+        return TypedItem{}, err
+    }
+    totalSeasons, err := parseNumber(i.TotalSeasons)
+    if err != nil {
+        return TypedItem{}, err
+    }
+    typed.TotalSeasons = int(totalSeasons)
+    return typed, nil
+}
+
+func naString(s string) string {
+    if s == notAvailable {
+        return ""
+    }
+    return s
+}
+
+// naList splits a comma separated field such as `"Short, Comedy"`.
+func naList(s string) []string {
+    if naString(s) == "" {
+        return nil
+    }
+    list := strings.Split(s, ",")
+    for i := range list {
+        list[i] = strings.TrimSpace(list[i])
+    }
+    return list
+}
+
+// parseYears parses `1893`, `1994–2004` or the still running `2005–`.
+func parseYears(s string) (int, int, error) {
+    s = naString(s)
+    if s == "" {
+        return 0, 0, nil
+    }
+
+    parts := strings.FieldsFunc(s, func(r rune) bool {
+        return r == '–' || r == '-'
+    })
+    if len(parts) == 0 || len(parts) > 2 {
+        return 0, 0, fmt.Errorf("invalid year %q", s)
+    }
+
+    start, err := strconv.Atoi(parts[0])
+    if err != nil {
+        return 0, 0, err
+    }
+    if len(parts) == 1 {
+        return start, 0, nil
+    }
+    end, err := strconv.Atoi(parts[1])
+    if err != nil {
+        return 0, 0, err
+    }
+    return start, end, nil
+}
+
+func parseDate(s string) (time.Time, error) {
+    if naString(s) == "" {
+        return time.Time{}, nil
+    }
+    return time.Parse(dateLayout, s)
+}
+
+// parseRuntime parses `92 min`.
+func parseRuntime(s string) (time.Duration, error) {
+    if naString(s) == "" {
+        return 0, nil
+    }
+    minutes, err := strconv.Atoi(strings.TrimSuffix(s, " min"))
+    if err != nil {
+        return 0, fmt.Errorf("invalid runtime %q", s)
+    }
+    return time.Duration(minutes) * time.Minute, nil
+}
+
+// parseNumber parses numbers with thousands separators such as `2,223`.
+func parseNumber(s string) (int64, error) {
+    if naString(s) == "" {
+        return 0, nil
+    }
+    return strconv.ParseInt(strings.Replace(s, ",", "", -1), 10, 64)
+}
+
+// parseRating parses an imdbRating such as `6.9`.
+func parseRating(s string) (float64, error) {
+    if naString(s) == "" {
+        return 0, nil
+    }
+    return strconv.ParseFloat(s, 64)
+}
@@ -0,0 +1,120 @@
+package omdb_test
+
+import (
+    "testing"
+    "time"
+
+    "github.com/stretchr/testify/require"
+
+    "Azarc/omdb"
+)
+
+func TestTyped(t *testing.T) {
+    testTable := []struct {
+        name          string
+        item          omdb.Item
+        expectedItem  omdb.TypedItem
+        expectedError string
+    }{
+        {
+            name: "movie",
+            item: omdb.Item{
+                Title:      "The Mask",
+                Year:       "1994",
+                Rated:      "PG-13",
+                Released:   "29 Jul 1994",
+                Runtime:    "101 min",
+                Genre:      "Comedy, Crime, Fantasy",
+                Director:   "Chuck Russell",
+                Writer:     "Michael Fallon, Mark Verheiden, Mike Werb",
+                Actors:     "Jim Carrey, Cameron Diaz",
+                Plot:       "Bank clerk Stanley Ipkiss is transformed.",
+                Language:   "English, Swedish",
+                Country:    "United States",
+                Awards:     "N/A",
+                Poster:     "https://m.media-amazon.com/images/M/mask.jpg",
+                Ratings:    []omdb.Rating{{Source: "Internet Movie Database", Value: "6.9/10"}},
+                Metascore:  "56",
+                ImdbRating: "6.9",
+                ImdbVotes:  "412,876",
+                ImdbID:     "tt0110475",
+                Type:       "movie",
+                DVD:        "22 Aug 2000",
+                BoxOffice:  "$119,938,730",
+                Production: "N/A",
+                Website:    "N/A",
+                Response:   "True",
+            },
+            expectedItem: omdb.TypedItem{
+                Title:      "The Mask",
+                StartYear:  1994,
+                Rated:      "PG-13",
+                Released:   time.Date(1994, time.July, 29, 0, 0, 0, 0, time.UTC),
+                Runtime:    101 * time.Minute,
+                Genres:     []string{"Comedy", "Crime", "Fantasy"},
+                Directors:  []string{"Chuck Russell"},
+                Writers:    []string{"Michael Fallon", "Mark Verheiden", "Mike Werb"},
+                Actors:     []string{"Jim Carrey", "Cameron Diaz"},
+                Plot:       "Bank clerk Stanley Ipkiss is transformed.",
+                Languages:  []string{"English", "Swedish"},
+                Countries:  []string{"United States"},
+                Poster:     "https://m.media-amazon.com/images/M/mask.jpg",
+                Ratings:    []omdb.Rating{{Source: "Internet Movie Database", Value: "6.9/10"}},
+                Metascore:  56,
+                ImdbRating: 6.9,
+                ImdbVotes:  412876,
+                ImdbID:     "tt0110475",
+                Type:       "movie",
+                DVD:        time.Date(2000, time.August, 22, 0, 0, 0, 0, time.UTC),
+                BoxOffice:  119938730,
+            },
+        },
+        {
+            name: "ended series",
+            item: omdb.Item{Year: "1994–2004", Type: "series", TotalSeasons: "10"},
+            expectedItem: omdb.TypedItem{
+                StartYear:    1994,
+                EndYear:      2004,
+                Type:         "series",
+                TotalSeasons: 10,
+            },
+        },
+        {
+            name: "running series",
+            item: omdb.Item{Year: "2005–"},
+            expectedItem: omdb.TypedItem{
+                StartYear: 2005,
+            },
+        },
+        {
+            name: "not available",
+            item: omdb.Item{
+                Year:       "N/A",
+                Released:   "N/A",
+                Runtime:    "N/A",
+                Genre:      "N/A",
+                Metascore:  "N/A",
+                ImdbRating: "N/A",
+                ImdbVotes:  "N/A",
+                BoxOffice:  "N/A",
+            },
+        },
+        {
+            name:          "invalid runtime",
+            item:          omdb.Item{Runtime: "1 h"},
+            expectedError: `invalid runtime "1 h"`,
+        },
+    }
+
+    for _, test := range testTable {
+        t.Run(test.name, func(t *testing.T) {
+            typed, err := test.item.Typed()
+            if test.expectedError != "" {
+                require.EqualError(t, err, test.expectedError)
+                return
+            }
+            require.NoError(t, err)
+            require.Equal(t, test.expectedItem, typed)
+        })
+    }
+}
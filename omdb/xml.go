@@ -0,0 +1,55 @@
+package omdb
+
+import (
+    "encoding/xml"
+)
+
+// Format is the response format requested from the omdbapi.
+type Format string
+
+const (
+    FormatJSON Format = "json"
+    FormatXML  Format = "xml"
+)
+
+// WithFormat requests Info and Title lookups in format f, both decoding into
+// the same Item. Searches and seasons are always requested as JSON.
+func WithFormat(f Format) Option {
+    return func(c *Client) {
+        c.format = f
+    }
+}
+
+// xmlRoot is the document returned by the omdbapi for `r=xml`, e.g.
+// `<root response="True"><movie title="The Mask" .../></root>`.
+type xmlRoot struct {
+    XMLName  xml.Name  `xml:"root"`
+    Response string    `xml:"response,attr"`
+    Movie    *xmlMovie `xml:"movie"`
+    Error    string    `xml:"error,omitempty"`
+}
+
+// xmlMovie has the fields and tags of Item without its XML methods.
+type xmlMovie Item
+
+// UnmarshalXML decodes an omdbapi XML document, the ratings being read from
+// the `rating` elements within `ratings`.
+func (i *Item) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+    var root xmlRoot
+    err := d.DecodeElement(&root, &start)
+    if err != nil {
+        return err
+    }
+    *i = Item{}
+    if root.Movie != nil {
+        *i = Item(*root.Movie)
+    }
+    i.Response = root.Response
+    return nil
+}
+
+// MarshalXML encodes the Item as an omdbapi XML document, e.g. for tools consuming the XML format.
+func (i Item) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+    movie := xmlMovie(i)
+    return e.Encode(xmlRoot{Response: i.Response, Movie: &movie})
+}
@@ -0,0 +1,126 @@
+package omdb_test
+
+import (
+    "context"
+    "encoding/xml"
+    "errors"
+    "io/ioutil"
+    "net/http"
+    "net/http/httptest"
+    "path/filepath"
+    "testing"
+
+    "github.com/stretchr/testify/require"
+
+    "Azarc/omdb"
+)
+
+func readFixture(t *testing.T, name string) []byte {
+    t.Helper()
+    data, err := ioutil.ReadFile(filepath.Join("testdata", name))
+    require.NoError(t, err)
+    return data
+}
+
+func TestInfo_XML(t *testing.T) {
+    ctx := context.Background()
+    fixtures := map[string]string{
+        "tt0110475": "movie.xml",
+        "tt0108778": "series.xml",
+        "tt0000000": "not_found.xml",
+    }
+
+    testServer := httptest.NewServer(
+        http.HandlerFunc(
+            func(writer http.ResponseWriter, req *http.Request) {
+                require.Equal(t, "xml", req.URL.Query().Get("r"))
+                writer.Header().Set("Content-Type", "text/xml; charset=utf-8")
+                _, err := writer.Write(readFixture(t, fixtures[req.URL.Query().Get("i")]))
+                require.NoError(t, err)
+            },
+        ),
+    )
+    defer testServer.Close()
+
+    client := omdb.NewWithURL(testServer.URL, "apiKey", omdb.WithFormat(omdb.FormatXML))
+
+    item, err := client.Info(ctx, "tt0110475")
+    require.NoError(t, err)
+    require.Equal(t, omdb.Item{
+        Title:      "The Mask",
+        Year:       "1994",
+        Rated:      "PG-13",
+        Released:   "29 Jul 1994",
+        Runtime:    "101 min",
+        Genre:      "Comedy, Crime, Fantasy",
+        Director:   "Chuck Russell",
+        Writer:     "Michael Fallon, Mark Verheiden, Mike Werb",
+        Actors:     "Jim Carrey, Cameron Diaz, Peter Riegert",
+        Plot:       "Bank clerk Stanley Ipkiss is transformed into a manic superhero when he wears a mysterious mask.",
+        Language:   "English, Swedish",
+        Country:    "United States",
+        Awards:     "Nominated for 1 Oscar. 11 wins & 23 nominations total",
+        Poster:     "https://m.media-amazon.com/images/M/MV5BOWExYjI5MzktNTRhNi00Nzg2LThkZmQtYWVkYjRlYWI2MDQ4XkEyXkFqcGdeQXVyNzY1NTI5NDc@._V1_SX300.jpg",
+        Ratings: []omdb.Rating{
+            {Source: "Internet Movie Database", Value: "6.9/10"},
+            {Source: "Rotten Tomatoes", Value: "79%"},
+            {Source: "Metacritic", Value: "56/100"},
+        },
+        Metascore:  "56",
+        ImdbRating: "6.9",
+        ImdbVotes:  "410,514",
+        ImdbID:     "tt0110475",
+        Type:       "movie",
+        DVD:        "18 Nov 1997",
+        BoxOffice:  "$119,938,730",
+        Production: "N/A",
+        Website:    "N/A",
+        Response:   "True",
+    }, item)
+
+    series, err := client.Info(ctx, "tt0108778")
+    require.NoError(t, err)
+    require.Equal(t, "series", series.Type)
+    require.Equal(t, "10", series.TotalSeasons)
+    typed, err := series.Typed()
+    require.NoError(t, err)
+    require.Equal(t, 1994, typed.StartYear)
+    require.Equal(t, 2004, typed.EndYear)
+    require.Equal(t, 1021337, typed.ImdbVotes)
+
+    _, err = client.Info(ctx, "tt0000000")
+    require.True(t, errors.Is(err, omdb.ErrNotFound))
+}
+
+func TestItem_XMLRoundTrip(t *testing.T) {
+    for _, fixture := range []string{"movie.xml", "series.xml"} {
+        t.Run(fixture, func(t *testing.T) {
+            var item omdb.Item
+            require.NoError(t, xml.Unmarshal(readFixture(t, fixture), &item))
+            require.NotEmpty(t, item.ImdbID)
+            require.NotEmpty(t, item.Ratings)
+
+            data, err := xml.Marshal(item)
+            require.NoError(t, err)
+
+            var decoded omdb.Item
+            require.NoError(t, xml.Unmarshal(data, &decoded))
+            require.Equal(t, item, decoded)
+
+            // the encoded document has the shape of the omdbapi response
+            var root struct {
+                Response string `xml:"response,attr"`
+                Movie    struct {
+                    ImdbID  string `xml:"imdbID,attr"`
+                    Ratings []struct {
+                        Source string `xml:"source,attr"`
+                    } `xml:"ratings>rating"`
+                } `xml:"movie"`
+            }
+            require.NoError(t, xml.Unmarshal(data, &root))
+            require.Equal(t, "True", root.Response)
+            require.Equal(t, item.ImdbID, root.Movie.ImdbID)
+            require.Len(t, root.Movie.Ratings, len(item.Ratings))
+        })
+    }
+}